@@ -1,187 +0,0 @@
-package main
-
-// All CBC mode cipher suites. This is used in BEAST attack vulnerability detection. While this list contains non-TLS 1.0 cipher suites, the existence checks are done behind a client version check. We'll just have extras that are never touched in this list.
-// Generated from:
-//   curl -s http://www.iana.org/assignments/tls-parameters/tls-parameters.txt | grep '0x.* TLS_' | grep CBC | awk '{ print $1": true,"}' | sed 's/,0x//'
-var cbcSuites = map[uint16]bool{
-	0x0006: true,
-	0x0007: true,
-	0x0008: true,
-	0x0009: true,
-	0x000A: true,
-	0x000B: true,
-	0x000C: true,
-	0x000D: true,
-	0x000E: true,
-	0x000F: true,
-	0x0010: true,
-	0x0011: true,
-	0x0012: true,
-	0x0013: true,
-	0x0014: true,
-	0x0015: true,
-	0x0016: true,
-	0x0019: true,
-	0x001A: true,
-	0x001B: true,
-	0x001E: true,
-	0x001F: true,
-	0x0021: true,
-	0x0022: true,
-	0x0023: true,
-	0x0025: true,
-	0x0026: true,
-	0x0027: true,
-	0x0029: true,
-	0x002A: true,
-	0x002F: true,
-	0x0030: true,
-	0x0031: true,
-	0x0032: true,
-	0x0033: true,
-	0x0034: true,
-	0x0035: true,
-	0x0036: true,
-	0x0037: true,
-	0x0038: true,
-	0x0039: true,
-	0x003A: true,
-	0x003C: true,
-	0x003D: true,
-	0x003E: true,
-	0x003F: true,
-	0x0040: true,
-	0x0041: true,
-	0x0042: true,
-	0x0043: true,
-	0x0044: true,
-	0x0045: true,
-	0x0046: true,
-	0x0067: true,
-	0x0068: true,
-	0x0069: true,
-	0x006A: true,
-	0x006B: true,
-	0x006C: true,
-	0x006D: true,
-	0x0084: true,
-	0x0085: true,
-	0x0086: true,
-	0x0087: true,
-	0x0088: true,
-	0x0089: true,
-	0x008B: true,
-	0x008C: true,
-	0x008D: true,
-	0x008F: true,
-	0x0090: true,
-	0x0091: true,
-	0x0093: true,
-	0x0094: true,
-	0x0095: true,
-	0x0096: true,
-	0x0097: true,
-	0x0098: true,
-	0x0099: true,
-	0x009A: true,
-	0x009B: true,
-	0x00AE: true,
-	0x00AF: true,
-	0x00B2: true,
-	0x00B3: true,
-	0x00B6: true,
-	0x00B7: true,
-	0x00BA: true,
-	0x00BB: true,
-	0x00BC: true,
-	0x00BD: true,
-	0x00BE: true,
-	0x00BF: true,
-	0x00C0: true,
-	0x00C1: true,
-	0x00C2: true,
-	0x00C3: true,
-	0x00C4: true,
-	0x00C5: true,
-	0xC003: true,
-	0xC004: true,
-	0xC005: true,
-	0xC008: true,
-	0xC009: true,
-	0xC00A: true,
-	0xC00D: true,
-	0xC00E: true,
-	0xC00F: true,
-	0xC012: true,
-	0xC013: true,
-	0xC014: true,
-	0xC017: true,
-	0xC018: true,
-	0xC019: true,
-	0xC01A: true,
-	0xC01B: true,
-	0xC01C: true,
-	0xC01D: true,
-	0xC01E: true,
-	0xC01F: true,
-	0xC020: true,
-	0xC021: true,
-	0xC022: true,
-	0xC023: true,
-	0xC024: true,
-	0xC025: true,
-	0xC026: true,
-	0xC027: true,
-	0xC028: true,
-	0xC029: true,
-	0xC02A: true,
-	0xC034: true,
-	0xC035: true,
-	0xC036: true,
-	0xC037: true,
-	0xC038: true,
-	0xC03C: true,
-	0xC03D: true,
-	0xC03E: true,
-	0xC03F: true,
-	0xC040: true,
-	0xC041: true,
-	0xC042: true,
-	0xC043: true,
-	0xC044: true,
-	0xC045: true,
-	0xC046: true,
-	0xC047: true,
-	0xC048: true,
-	0xC049: true,
-	0xC04A: true,
-	0xC04B: true,
-	0xC04C: true,
-	0xC04D: true,
-	0xC04E: true,
-	0xC04F: true,
-	0xC064: true,
-	0xC065: true,
-	0xC066: true,
-	0xC067: true,
-	0xC068: true,
-	0xC069: true,
-	0xC070: true,
-	0xC071: true,
-	0xC072: true,
-	0xC073: true,
-	0xC074: true,
-	0xC075: true,
-	0xC076: true,
-	0xC077: true,
-	0xC078: true,
-	0xC079: true,
-	0xC094: true,
-	0xC095: true,
-	0xC096: true,
-	0xC097: true,
-	0xC098: true,
-	0xC099: true,
-	0xC09A: true,
-	0xC09B: true,
-}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,38 +8,27 @@ import (
 	"flag"
 	"fmt"
 	"html/template"
-	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
-	"net/http/httputil"
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
 
 	"github.com/sullivanmatt/check.tls.support/gzip"
 	tls "github.com/sullivanmatt/check.tls.support/tls110"
+	"github.com/sullivanmatt/check.tls.support/verify"
+	"golang.org/x/net/http2"
 )
 
 const (
-	resp500Format = `HTTP/1.%d 500 Internal Server Error
-Content-Length: 26
-Connection: close
-Content-Type: text/plain; charset="utf-8"
-Strict-Transport-Security: max-age=31536000; includeSubdomains; preload
-Date: %s
-
-500 Internal Server Error
-`
 	hstsHeaderValue = "max-age=31536000; includeSubdomains; preload"
 	xForwardedProto = "X-Forwarded-Proto"
 )
@@ -58,7 +46,18 @@ var (
 	tmplDir        = flag.String("templateDir", "./templates", "file path to the directory of templates")
 	adminAddr      = flag.String("adminAddr", "localhost:4567", "address to boot the admin server on")
 	headless       = flag.Bool("headless", false, "Run without templates")
-	hmacSecret     = flag.String("hmacSecret", "", "hmac secret (for signatures)")
+	hmacSecret     = flag.String("hmacSecret", "", "hmac secret (for signatures); deprecated because it shows up in ps, use -hmacSecretsFile or -hmacSecretsEnv")
+	hmacKeysPath   = flag.String("hmacSecretsFile", "", "file path to the JSON HMAC secrets to sign API responses with, reloaded every 20 seconds and on SIGHUP")
+	hmacKeysEnv    = flag.String("hmacSecretsEnv", "", "name of the environment variable holding the HMAC secrets, as JSON like -hmacSecretsFile or a single secret")
+	rejectFallback = flag.Bool("rejectFallbackSCSV", false, "abort handshakes that send TLS_FALLBACK_SCSV below our max version instead of reporting them")
+	curves         = flag.String("curves", "x25519,secp256r1,secp384r1,secp521r1", "comma-separated groups to use for ECDHE key exchange, in preference order")
+	dhGroupBits    = flag.Int("dhGroupBits", 2048, "size of the group used for DHE key exchange on the HTTPS servers (only tls110 does DHE)")
+	dhProbeAddrs   = flag.String("dhProbeAddrs", "", "comma-separated bits=addr pairs of extra HTTPS servers that only do DHE with a group of that size (512, 768, 1024 or 2048), to probe what clients accept")
+	policyFile     = flag.String("ratingPolicyFile", "", "file path to a JSON rating policy that overrides the default rating rules")
+	clientsFile    = flag.String("fingerprintsFile", "", "file path to the JSON database of known client fingerprints")
+	signingKeys    = flag.String("signingKeysFile", "", "file path to the JSON list of PEM private keys to sign API responses with, whose public keys are served at /.well-known/jwks.json")
+	resultTTL      = flag.Duration("resultTTL", 5*time.Minute, "how long signed API results are valid for after they're issued")
+	legacyAddr     = flag.String("legacyHTTPSAddr", "", "address to boot an extra HTTPS server on that always uses tls110, rather than only for the clients crypto/tls can't serve")
 
 	apiVars         = expvar.NewMap("api")
 	staticVars      = expvar.NewMap("static")
@@ -75,10 +74,11 @@ var (
 
 	index *template.Template
 
-	// liveHijackCount is for counting hijacked connections so that we can do
-	// clean shutdowns. It's global state because this app is small and we only
-	// use it in this file.
-	liveHijackCount = newUint64()
+	// liveHTTP2Count is for counting HTTP/2 connections, which the
+	// http.Server doesn't track, so that we can do clean shutdowns. It's
+	// global state because this app is small and we only use it in this
+	// file and serve.go.
+	liveHTTP2Count = newUint64()
 )
 
 func main() {
@@ -97,13 +97,38 @@ func main() {
 	staticVars.Set("requests", staticRequests)
 	webVars.Set("requests", webRequests)
 
-	if len(*hmacSecret) == 0 {
-		log.Fatalf("hmacSecret cannot be empty.")
+	hmacFlagsSet := 0
+	for _, v := range []string{*hmacKeysPath, *hmacKeysEnv, *hmacSecret} {
+		if v != "" {
+			hmacFlagsSet++
+		}
+	}
+	if hmacFlagsSet != 1 {
+		log.Fatalf("exactly one of -hmacSecretsFile, -hmacSecretsEnv and -hmacSecret must be set")
+	}
+	var hmacKeys *hmacKeySet
+	var err error
+	switch {
+	case *hmacKeysPath != "":
+		hmacKeys, err = loadHMACKeysFile(*hmacKeysPath)
+	case *hmacKeysEnv != "":
+		hmacKeys, err = loadHMACKeysEnv(*hmacKeysEnv)
+	default:
+		hmacKeys = singleHMACKey(*hmacSecret)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	hkr := newHMACKeyReloader(*hmacKeysPath, hmacKeys)
+	if *hmacKeysPath != "" {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go reloadHMACKeysForever(hkr, time.NewTicker(20*time.Second), hup)
 	}
 
 	tlsConf := makeTLSConfig(*certPath, *keyPath)
 
-	tlsListener, err := tls.Listen("tcp", *httpsAddr, tlsConf)
+	httpsListener, err := net.Listen("tcp", *httpsAddr)
 	if err != nil {
 		log.Fatalf("unable to listen for the HTTPS server on %s: %s", *httpsAddr, err)
 	}
@@ -112,7 +137,33 @@ func main() {
 		log.Fatalf("unable to listen for the HTTP server on %s: %s", *httpAddr, err)
 	}
 	ns := expvar.NewMap("tls")
-	l := newListener(tlsListener, ns)
+	l := newStdlibListener(httpsListener, tlsConf, ns)
+
+	// crypto/tls serves the main HTTPS server, handing the clients it can't
+	// serve, like SSL 3.0 ones, to tls110. The legacy server always uses
+	// tls110.
+	var legacyListener net.Listener
+	if *legacyAddr != "" {
+		ll, err := tls.Listen("tcp", *legacyAddr, tlsConf)
+		if err != nil {
+			log.Fatalf("unable to listen for the legacy HTTPS server on %s: %s", *legacyAddr, err)
+		}
+		legacyListener = newListener(ll, expvar.NewMap("tls_legacy"))
+	}
+
+	probes, err := parseDHProbes(*dhProbeAddrs)
+	if err != nil {
+		log.Fatalf("unable to parse -dhProbeAddrs: %s", err)
+	}
+	var probeListeners []net.Listener
+	for _, p := range probes {
+		pl, err := tls.Listen("tcp", p.addr, makeDHProbeConfig(tlsConf, p.bits))
+		if err != nil {
+			log.Fatalf("unable to listen for the %d-bit DH probe server on %s: %s", p.bits, p.addr, err)
+		}
+		pns := expvar.NewMap(fmt.Sprintf("tls_dh%d", p.bits))
+		probeListeners = append(probeListeners, newListener(pl, pns))
+	}
 
 	if *acmeURL != "" {
 		if !strings.HasPrefix(*acmeURL, "/") &&
@@ -140,6 +191,42 @@ func main() {
 		go reloadAllowMapsForever(*allowListsFile, ama, alTick)
 	}
 
+	rpa := &ratingPolicyAtomic{}
+	rpa.Store(defaultRatingPolicy)
+	if *policyFile != "" {
+		rp, err := loadRatingPolicy(*policyFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rpa.Store(rp)
+		rpTick := time.NewTicker(20 * time.Second)
+		go reloadRatingPolicyForever(*policyFile, rpa, rpTick)
+	}
+
+	fdba := &fingerprintDBAtomic{}
+	fdba.Store(emptyFingerprintDB)
+	if *clientsFile != "" {
+		db, err := loadFingerprintDB(*clientsFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fdba.Store(db)
+		fdbTick := time.NewTicker(20 * time.Second)
+		go reloadFingerprintDBForever(*clientsFile, fdba, fdbTick)
+	}
+
+	jksa := &jwsKeySetAtomic{}
+	jksa.Store(emptyJWSKeySet)
+	if *signingKeys != "" {
+		ks, err := loadJWSKeySet(*signingKeys)
+		if err != nil {
+			log.Fatal(err)
+		}
+		jksa.Store(ks)
+		jksTick := time.NewTicker(20 * time.Second)
+		go reloadJWSKeySetForever(*signingKeys, jksa, jksTick)
+	}
+
 	hostname, err := os.Hostname()
 	if err != nil {
 		log.Fatalf("unable to get hostname of local machine: %s", err)
@@ -154,7 +241,7 @@ func main() {
 	if !*headless {
 		index = loadIndex()
 		staticHandler = makeStaticHandler(*staticDir, staticVars)
-		webHandleFunc = handleWeb
+		webHandleFunc = handleWeb(rpa, fdba)
 	}
 
 	m := tlsMux(
@@ -162,6 +249,10 @@ func main() {
 		staticHandler,
 		webHandleFunc,
 		oa,
+		rpa,
+		fdba,
+		jksa,
+		hkr,
 	)
 
 	go func() {
@@ -175,6 +266,13 @@ func main() {
 		Handler:      m,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+		ConnContext:  connContext,
+	}
+	h2s := &http2.Server{}
+	err = http2.ConfigureServer(httpsSrv, h2s)
+	if err != nil {
+		log.Fatalf("unable to configure HTTP/2: %s", err)
 	}
 
 	httpSrv := &http.Server{
@@ -187,11 +285,29 @@ func main() {
 
 	log.Printf("Booting HTTPS on %s and HTTP on %s", *httpsAddr, *httpAddr)
 	go func() {
-		err := httpsSrv.Serve(l)
+		err := serveTLS(httpsSrv, h2s, l)
 		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("https server error: %s", err)
 		}
 	}()
+	if legacyListener != nil {
+		log.Printf("Booting legacy HTTPS on %s", *legacyAddr)
+		go func() {
+			err := serveTLS(httpsSrv, h2s, legacyListener)
+			if err != nil && err != http.ErrServerClosed {
+				log.Fatalf("legacy https server error: %s", err)
+			}
+		}()
+	}
+	for i, pl := range probeListeners {
+		log.Printf("Booting %d-bit DH probe HTTPS on %s", probes[i].bits, probes[i].addr)
+		go func(pl net.Listener) {
+			err := serveTLS(httpsSrv, h2s, pl)
+			if err != nil && err != http.ErrServerClosed {
+				log.Fatalf("DH probe https server error: %s", err)
+			}
+		}(pl)
+	}
 	go func() {
 		err := httpSrv.Serve(plaintextListener)
 		if err != nil && err != http.ErrServerClosed {
@@ -218,20 +334,21 @@ func main() {
 		}
 	}()
 	wg.Wait()
-	for atomic.LoadUint64(liveHijackCount) != 0 && ctx.Err() == nil {
+	for atomic.LoadUint64(liveHTTP2Count) != 0 && ctx.Err() == nil {
 		time.Sleep(100 * time.Millisecond)
 	}
 	cancel()
 	gclog.Flush()
 }
 
-func tlsMux(acmeRedirectURL string, staticHandler http.Handler, webHandleFunc http.HandlerFunc, oa *originAllower) http.Handler {
+func tlsMux(acmeRedirectURL string, staticHandler http.Handler, webHandleFunc http.HandlerFunc, oa *originAllower, rpa *ratingPolicyAtomic, fdba *fingerprintDBAtomic, jksa *jwsKeySetAtomic, hkr *hmacKeyReloader) http.Handler {
 	acmeRedirectURL = strings.TrimRight(acmeRedirectURL, "/")
 	m := http.NewServeMux()
 	//m.Handle("/s/", staticHandler)
-	//m.Handle("/a/check", &apiHandler{oa: oa})
+	//m.Handle("/a/check", &apiHandler{oa: oa, rpa: rpa, fdba: fdba, jksa: jksa, hkr: hkr})
 	//m.HandleFunc("/", webHandleFunc)
-	m.Handle("/", &apiHandler{oa: oa})
+	m.Handle("/", &apiHandler{oa: oa, rpa: rpa, fdba: fdba, jksa: jksa, hkr: hkr})
+	m.HandleFunc("/.well-known/jwks.json", handleJWKS(jksa))
 	m.HandleFunc("/healthcheck", healthcheck)
 	return protoHandler{logHandler{m}, "https"}
 }
@@ -245,16 +362,27 @@ func plaintextMux() http.Handler {
 
 const htmlContentType = "text/html;charset=utf-8"
 
-func renderHTML(r *http.Request, data *clientInfo) ([]byte, int, string, string, error) {
+// renderFunc renders a clientInfo as a response body with its status code,
+// content type and signatures.
+type renderFunc func(*http.Request, *clientInfo) ([]byte, int, string, signatures, error)
+
+// signatures are the signatures of a response body, sent in its headers.
+type signatures struct {
+	hmac    string // X-Response-Signature
+	hmacKID string // X-Response-Signature-Kid, the kid of the HMAC secret
+	jws     string // X-Response-JWS, with the payload detached
+}
+
+func renderHTML(r *http.Request, data *clientInfo) ([]byte, int, string, signatures, error) {
 	b := new(bytes.Buffer)
 	err := index.Execute(b, data)
 	if err != nil {
-		return nil, 0, "", "", err
+		return nil, 0, "", signatures{}, err
 	}
-	return b.Bytes(), http.StatusOK, htmlContentType, "", nil
+	return b.Bytes(), http.StatusOK, htmlContentType, signatures{}, nil
 }
 
-func disallowedRenderJSON(r *http.Request, data *clientInfo) ([]byte, int, string, string, error) {
+func disallowedRenderJSON(r *http.Request, data *clientInfo) ([]byte, int, string, signatures, error) {
 	//callback := r.FormValue("callback")
 	//sanitizedCallback := nonAlphaNumeric.ReplaceAll([]byte(callback), []byte(""))
 
@@ -264,36 +392,46 @@ func disallowedRenderJSON(r *http.Request, data *clientInfo) ([]byte, int, strin
 	//	return body, http.StatusOK, "application/javascript", nil
 
 	//}
-	return disallowedOriginBody, http.StatusBadRequest, "application/json", "", nil
+	return disallowedOriginBody, http.StatusBadRequest, "application/json", signatures{}, nil
 }
 
-func allowedRenderJSON(r *http.Request, data *clientInfo) ([]byte, int, string, string, error) {
-	//callback := r.FormValue("callback")
-	//sanitizedCallback := nonAlphaNumeric.ReplaceAll([]byte(callback), []byte(""))
-
-	marshalled, err := json.MarshalIndent(data, "", "    ")
-	if err != nil {
-		return nil, 0, htmlContentType, "", err
-	}
-	//if len(sanitizedCallback) > 0 {
-	//	return []byte(fmt.Sprintf("%s(%s);", sanitizedCallback, marshalled)), http.StatusOK, "application/javascript", nil
-	//}
+// allowedRenderJSON returns a renderFunc that adds claims to the JSON it
+// renders and signs it with hks and, if there's a signing key, jks.
+func allowedRenderJSON(hks *hmacKeySet, jks *jwsKeySet, claims *verify.Claims) renderFunc {
+	return func(r *http.Request, data *clientInfo) ([]byte, int, string, signatures, error) {
+		//callback := r.FormValue("callback")
+		//sanitizedCallback := nonAlphaNumeric.ReplaceAll([]byte(callback), []byte(""))
 
-        // Compute and attach signature
-        hash := hmac.New(sha256.New, []byte(*hmacSecret))
-        hash.Write([]byte(marshalled))
-        sha := base64.StdEncoding.EncodeToString(hash.Sum(nil))
+		data.Claims = claims
+		marshalled, err := json.MarshalIndent(data, "", "    ")
+		if err != nil {
+			return nil, 0, htmlContentType, signatures{}, err
+		}
+		//if len(sanitizedCallback) > 0 {
+		//	return []byte(fmt.Sprintf("%s(%s);", sanitizedCallback, marshalled)), http.StatusOK, "application/javascript", nil
+		//}
+
+		// Compute and attach signatures
+		var sigs signatures
+		sigs.hmac, sigs.hmacKID = hks.sign(marshalled)
+		sigs.jws, err = jks.sign(marshalled)
+		if err != nil {
+			return nil, 0, htmlContentType, signatures{}, err
+		}
 
-	return marshalled, http.StatusOK, "application/json", sha, nil
+		return marshalled, http.StatusOK, "application/json", sigs, nil
+	}
 }
 
-func handleWeb(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Path != "/" {
-		http.Error(w, "404 Not Found", http.StatusNotFound)
-		return
+func handleWeb(rpa *ratingPolicyAtomic, fdba *fingerprintDBAtomic) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.Error(w, "404 Not Found", http.StatusNotFound)
+			return
+		}
+		webRequests.Add(1)
+		serveClientInfo(w, r, rpa.Load(), fdba.Load(), complianceProfiles, webStatuses, renderHTML)
 	}
-	webRequests.Add(1)
-	hijackHandle(w, r, webStatuses, renderHTML)
 }
 
 var (
@@ -307,16 +445,34 @@ var (
 )
 
 type apiHandler struct {
-	oa *originAllower
+	oa   *originAllower
+	rpa  *ratingPolicyAtomic
+	fdba *fingerprintDBAtomic
+	jksa *jwsKeySetAtomic
+	hkr  *hmacKeyReloader
 }
 
 func (ah *apiHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	apiRequests.Add(1)
 
+	profiles, err := parseComplianceProfiles(r.FormValue("profile"))
+	if err != nil {
+		apiStatuses.status4xx.Add(1)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, err := newResultClaims(r, time.Now(), *resultTTL)
+	if err != nil {
+		apiStatuses.status4xx.Add(1)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	detectedDomain, ok := ah.oa.Allow(r)
 	ok = true
 
-	renderJSON := allowedRenderJSON
+	renderJSON := allowedRenderJSON(ah.hkr.Keys(), ah.jksa.Load(), claims)
 	if ok {
 		log.Printf("allowed domain: %#v; Origin: %#v; Referrer: %#v", detectedDomain, r.Header.Get("Origin"), r.Header.Get("Referer"))
 	} else {
@@ -324,84 +480,53 @@ func (ah *apiHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		log.Printf("disallowed domain: %#v; Origin: %#v; Referrer: %#v", detectedDomain, r.Header.Get("Origin"), r.Header.Get("Referer"))
 	}
 
-	hijackHandle(w, r, apiStatuses, renderJSON)
+	serveClientInfo(w, r, ah.rpa.Load(), ah.fdba.Load(), profiles, apiStatuses, renderJSON)
 }
 
-func hijackHandle(w http.ResponseWriter, r *http.Request, statuses *statusStats, render func(*http.Request, *clientInfo) ([]byte, int, string, string, error)) {
-	hj, ok := w.(http.Hijacker)
+func serveClientInfo(w http.ResponseWriter, r *http.Request, rp *ratingPolicy, fdb *fingerprintDB, profiles []*complianceProfile, statuses *statusStats, render renderFunc) {
+	w = &statWriter{w: w, stats: statuses}
+	tc, ok := connFromRequest(r)
 	if !ok {
-		log.Printf("server not hijackable\n")
+		log.Printf("Unable to find the *conn of the request from %s\n", r.RemoteAddr)
 		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
 		return
 	}
+	data := pullClientInfo(tc, rp, fdb)
+	st := tc.ConnectionState()
+	data.Compliance = checkCompliance(data, &st, profiles)
+	data.InterceptionReasons = interceptionReasons(r.Header, data, &st)
+	data.InterceptionSuspected = len(data.InterceptionReasons) != 0
 
-	c, brw, err := hj.Hijack()
-	if err != nil {
-		log.Printf("server errored during hijack: %s\n", err)
-		return
-	}
-	incrementHijack()
-	defer decrementHijack()
-	defer c.Close()
-	tc, ok := c.(*conn)
-	if !ok {
-		log.Printf("Unable to convert net.Conn to *conn: %#v\n", c)
-		hijacked500(brw, r.ProtoMinor, statuses)
-		return
-	}
-	data := pullClientInfo(tc)
-
-	bs, status, contentType, signature, err := render(r, data)
+	bs, status, contentType, sigs, err := render(r, data)
 	if err != nil {
 		log.Printf("Unable to execute render: %s\n", err)
-		hijacked500(brw, r.ProtoMinor, statuses)
-		return
-	}
-	contentLength := int64(len(bs))
-	h := make(http.Header)
-	defaultResponseHeaders(h, r, contentType, signature)
-	resp := &http.Response{
-		StatusCode:    status,
-		ContentLength: contentLength,
-		Header:        h,
-		Body:          ioutil.NopCloser(bytes.NewBuffer(bs)),
-		ProtoMajor:    1, // Assumes HTTP/1.x
-		ProtoMinor:    r.ProtoMinor,
-	}
-	bs, err = httputil.DumpResponse(resp, true)
-	if err != nil {
-		log.Printf("unable to write response: %s\n", err)
-		hijacked500(brw, r.ProtoMinor, statuses)
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
 		return
 	}
-	statuses.status2xx.Add(1)
-	brw.Write(bs)
-	brw.Flush()
+	defaultResponseHeaders(w.Header(), r, contentType, sigs)
+	w.Header().Set("Content-Length", strconv.Itoa(len(bs)))
+	w.WriteHeader(status)
+	w.Write(bs)
 }
 
-func defaultResponseHeaders(h http.Header, r *http.Request, contentType string, signature string) {
+func defaultResponseHeaders(h http.Header, r *http.Request, contentType string, sigs signatures) {
 	h.Set("Date", time.Now().Format(http.TimeFormat))
 	h.Set("Content-Type", contentType)
-	h.Set("X-Response-Signature", signature)
-	if r.ProtoMajor == 1 && r.ProtoMinor == 1 {
-		h.Set("Connection", "close")
+	h.Set("X-Response-Signature", sigs.hmac)
+	if sigs.hmacKID != "" {
+		h.Set("X-Response-Signature-Kid", sigs.hmacKID)
+	}
+	if sigs.jws != "" {
+		h.Set("X-Response-JWS", sigs.jws)
 	}
 	h.Set("Strict-Transport-Security", hstsHeaderValue)
 	// Allow CORS requests from any domain, for easy API access
 	h.Set("Access-Control-Allow-Origin", "*")
-	h.Set("Access-Control-Allow-Headers", "X-Response-Signature, Content-Type")
-	h.Set("Access-Control-Expose-Headers", "X-Response-Signature, Content-Type")
+	h.Set("Access-Control-Allow-Headers", "X-Response-Signature, X-Response-Signature-Kid, X-Response-JWS, Content-Type")
+	h.Set("Access-Control-Expose-Headers", "X-Response-Signature, X-Response-Signature-Kid, X-Response-JWS, Content-Type")
 	h.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, HEAD")
 
 }
-func hijacked500(brw *bufio.ReadWriter, protoMinor int, statuses *statusStats) {
-	statuses.status5xx.Add(1)
-	// Assumes HTTP/1.x
-	s := fmt.Sprintf(resp500Format, protoMinor, time.Now().Format(http.TimeFormat))
-	brw.WriteString(s)
-	brw.Flush()
-}
-
 func healthcheck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(200)
 	w.Write([]byte("ok"))
@@ -434,11 +559,18 @@ func makeTLSConfig(certPath, keyPath string) *tls.Config {
 		log.Fatalf("unable to load TLS key cert pair %s: %s", certPath, err)
 	}
 	go reloadKeypairForever(kpr, time.NewTicker(1*time.Hour))
+	curvePrefs, err := parseGroupNames(*curves)
+	if err != nil {
+		log.Fatalf("unable to parse -curves: %s", err)
+	}
 	tlsConf := &tls.Config{
-		GetCertificate:           kpr.GetCertificate,
-		NextProtos:               []string{"https"},
-		PreferServerCipherSuites: true,
-		MinVersion:               tls.VersionSSL30,
+		GetCertificate:             kpr.GetCertificate,
+		NextProtos:                 []string{http2.NextProtoTLS, "http/1.1"},
+		PreferServerCipherSuites:   true,
+		MinVersion:                 tls.VersionSSL30,
+		AllowInappropriateFallback: !*rejectFallback,
+		CurvePreferences:           curvePrefs,
+		DHGroupBits:                *dhGroupBits,
 		CipherSuites: []uint16{
 			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
 			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
@@ -454,6 +586,12 @@ func makeTLSConfig(certPath, keyPath string) *tls.Config {
 			tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
 			tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
 			tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+			tls.TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_DHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_DHE_RSA_WITH_AES_128_CBC_SHA256,
+			tls.TLS_DHE_RSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_DHE_RSA_WITH_AES_256_CBC_SHA,
 			tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
 			tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
 			tls.TLS_RSA_WITH_RC4_128_SHA,
@@ -461,6 +599,7 @@ func makeTLSConfig(certPath, keyPath string) *tls.Config {
 			tls.TLS_RSA_WITH_AES_128_CBC_SHA256,
 			tls.TLS_RSA_WITH_AES_256_CBC_SHA,
 			tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA,
+			tls.TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA,
 			tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
 		},
 	}
@@ -504,8 +643,7 @@ type logHandler struct {
 	inner http.Handler
 }
 
-// Since we have a Hijack in our code, this simple writer will suffice for
-// now.
+// logHandler prints a line about each request before serving it.
 func (h logHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
@@ -571,21 +709,21 @@ func newUint64() *uint64 {
 	return &i
 }
 
-func incrementHijack() {
+func incrementHTTP2Conns() {
 	for {
-		old := atomic.LoadUint64(liveHijackCount)
+		old := atomic.LoadUint64(liveHTTP2Count)
 		new := old + 1
-		if atomic.CompareAndSwapUint64(liveHijackCount, old, new) {
+		if atomic.CompareAndSwapUint64(liveHTTP2Count, old, new) {
 			break
 		}
 	}
 }
 
-func decrementHijack() {
+func decrementHTTP2Conns() {
 	for {
-		old := atomic.LoadUint64(liveHijackCount)
+		old := atomic.LoadUint64(liveHTTP2Count)
 		new := old - 1
-		if atomic.CompareAndSwapUint64(liveHijackCount, old, new) {
+		if atomic.CompareAndSwapUint64(liveHTTP2Count, old, new) {
 			break
 		}
 	}
@@ -1,11 +1,10 @@
 package main
 
 import (
-	"fmt"
 	"strconv"
-	"strings"
 
 	tls "github.com/sullivanmatt/check.tls.support/tls110"
+	"github.com/sullivanmatt/check.tls.support/verify"
 )
 
 type rating string
@@ -25,24 +24,69 @@ const (
 )
 
 type clientInfo struct {
-	SupportedCipherSuites          []string            `json:"supported_cipher_suites"`
-	WeakCipherSuites               map[string][]string `json:"weak_cipher_suites"`
-	BrokenCipherSuites             map[string][]string `json:"broken_cipher_suites"`
-	EphemeralKeysSupported         bool                `json:"ephemeral_keys_supported"`             // good if true
-	SessionTicketsSupported        bool                `json:"session_ticket_supported"`             // good if true
-	TLSCompressionSupported        bool                `json:"tls_compression_supported"`            // bad if true
-	UnknownCipherSuiteSupported    bool                `json:"unknown_cipher_suite_supported"`       // bad if true
-	BEASTVuln                      bool                `json:"beast_vuln"`                           // bad if true
-	AbleToDetectNMinusOneSplitting bool                `json:"able_to_detect_n_minus_one_splitting"` // neutral
-	TLSVersion                     string              `json:"tls_version"`
-	TLSVersionFloat                float64             `json:"tls_version_float"`
-	Rating                         rating              `json:"rating"`
-	RatingScore                    rating_score        `json:"rating_score"`
+	SupportedCipherSuites          []string                    `json:"supported_cipher_suites"`
+	WeakCipherSuites               map[string][]string         `json:"weak_cipher_suites"`
+	BrokenCipherSuites             map[string][]string         `json:"broken_cipher_suites"`
+	EphemeralKeysSupported         bool                        `json:"ephemeral_keys_supported"`             // good if true
+	SessionTicketsSupported        bool                        `json:"session_ticket_supported"`             // good if true
+	TLSCompressionSupported        bool                        `json:"tls_compression_supported"`            // bad if true
+	UnknownCipherSuiteSupported    bool                        `json:"unknown_cipher_suite_supported"`       // bad if true
+	BEASTVuln                      bool                        `json:"beast_vuln"`                           // bad if true
+	AbleToDetectNMinusOneSplitting bool                        `json:"able_to_detect_n_minus_one_splitting"` // neutral
+	TLSVersion                     string                      `json:"tls_version"`
+	TLSVersionFloat                float64                     `json:"tls_version_float"`
+	Rating                         rating                      `json:"rating"`
+	RatingScore                    rating_score                `json:"rating_score"`
+	FailedRatingRules              []ratingRuleResult          `json:"failed_rating_rules"`
+	ClientHello                    []byte                      `json:"client_hello"`
+	ClientHelloExtensions          []clientHelloExtension      `json:"client_hello_extensions"`
+	JA3                            string                      `json:"ja3"`
+	JA3Hash                        string                      `json:"ja3_hash"`
+	JA4                            string                      `json:"ja4"`
+	IdentifiedClient               *identifiedClient           `json:"identified_client"` // null if no known client matched
+	OfferedGroups                  []string                    `json:"offered_groups"`
+	KeyShareGroups                 []string                    `json:"key_share_groups"`
+	PostQuantumReady               bool                        `json:"post_quantum_ready"` // good if true
+	GREASE                         greaseInfo                  `json:"grease"`
+	ExtendedMasterSecretSupported  bool                        `json:"extended_master_secret_supported"` // good if true
+	EncryptThenMACSupported        bool                        `json:"encrypt_then_mac_supported"`       // neutral
+	SecureRenegotiationSupported   bool                        `json:"secure_renegotiation_supported"`   // good if true
+	EmptyRenegotiationInfoSCSV     bool                        `json:"renegotiation_info_scsv"`          // neutral
+	SignatureAlgorithms            []string                    `json:"signature_algorithms"`
+	SignatureAlgorithmsCert        []string                    `json:"signature_algorithms_cert"`
+	WeakSignatureAlgorithms        map[string][]string         `json:"weak_signature_algorithms"` // improvable if not empty, bad if MD5 is included
+	SupportedGroups                []namedGroup                `json:"supported_groups"`
+	PointFormats                   []string                    `json:"point_formats"`
+	WeakGroups                     map[string][]string         `json:"weak_groups"`
+	LowSecurityGroupSupported      bool                        `json:"low_security_group_supported"` // improvable if true
+	ECH                            echInfo                     `json:"encrypted_client_hello"`       // neutral
+	FallbackSCSVSent               bool                        `json:"fallback_scsv_sent"`           // improvable if true
+	FallbackSCSVReason             string                      `json:"fallback_scsv_reason,omitempty"`
+	LogjamVuln                     bool                        `json:"logjam_vuln"`            // bad if true
+	WeakDHGroupAccepted            bool                        `json:"weak_dh_group_accepted"` // improvable if true
+	DHGroupReason                  string                      `json:"dh_group_reason,omitempty"`
+	Vulnerabilities                []vulnerabilityFinding      `json:"vulnerabilities"`
+	Negotiated                     negotiatedInfo              `json:"negotiated"`
+	Compliance                     map[string]complianceResult `json:"compliance"`
+	InterceptionSuspected          bool                        `json:"interception_suspected"`
+	InterceptionReasons            []string                    `json:"interception_reasons"`
+	Score                          scoreInfo                   `json:"score"`
+	Claims                         *verify.Claims              `json:"claims,omitempty"` // only in API results
+
+	version         uint16
+	md5SigSupported bool
+}
+
+// clientHelloExtension is one extension of the client's ClientHello, in the
+// order it was sent.
+type clientHelloExtension struct {
+	Type   uint16 `json:"type"`
+	Length int    `json:"length"`
+	Data   []byte `json:"data"`
 }
 
 const (
-	versionTLS13        uint16 = 0x0304
-	versionTLS13Draft18        = 0x7f00 | 18
+	versionTLS13Draft18 uint16 = 0x7f00 | 18
 	versionTLS13Draft21        = 0x7f00 | 21
 	versionTLS13Draft22        = 0x7f00 | 22
 	versionTLS13Draft23        = 0x7f00 | 23
@@ -63,8 +107,7 @@ var actualSupportedVersions = map[uint16]string{
 	tls.VersionTLS10:    "TLS 1.0",
 	tls.VersionTLS11:    "TLS 1.1",
 	tls.VersionTLS12:    "TLS 1.2",
-	versionTLS13:        "TLS 1.3", // TODO(#119): use crypto/tls's constant when it has it
-	//tls.VersionTLS13:    "TLS 1.3",
+	tls.VersionTLS13:    "TLS 1.3",
 	versionTLS13Draft18: "TLS 1.3",
 	versionTLS13Draft21: "TLS 1.3",
 	versionTLS13Draft22: "TLS 1.3",
@@ -81,8 +124,13 @@ var actualSupportedVersions = map[uint16]string{
 	versionTLS13Draft33: "TLS 1.3",
 }
 
-func pullClientInfo(c *conn) *clientInfo {
-	d := &clientInfo{BrokenCipherSuites: make(map[string][]string), WeakCipherSuites: make(map[string][]string)}
+func pullClientInfo(c *conn, rp *ratingPolicy, fdb *fingerprintDB) *clientInfo {
+	d := &clientInfo{
+		BrokenCipherSuites:      make(map[string][]string),
+		WeakCipherSuites:        make(map[string][]string),
+		WeakSignatureAlgorithms: make(map[string][]string),
+		WeakGroups:              make(map[string][]string),
+	}
 
 	st := c.ConnectionState()
 	if !st.HandshakeComplete {
@@ -90,57 +138,110 @@ func pullClientInfo(c *conn) *clientInfo {
 	}
 	var sweet32Seen []string
 	for _, ci := range st.ClientCipherSuites {
-		s, found := allCipherSuites[ci]
-		if found {
-			if strings.Contains(s, "DHE_") {
-				d.EphemeralKeysSupported = true
+		cs, found := cipherSuites[ci]
+		if !found {
+			d.UnknownCipherSuiteSupported = true
+			d.SupportedCipherSuites = append(d.SupportedCipherSuites, cipherSuiteName(ci))
+			continue
+		}
+		s := cs.Name
+		if cs.forwardSecret() {
+			d.EphemeralKeysSupported = true
+		}
+		for _, r := range cs.weakReasons() {
+			d.WeakCipherSuites[s] = append(d.WeakCipherSuites[s], r)
+		}
+		if cs.Mode == "CBC" && st.Version <= tls.VersionTLS10 {
+			// Without having seen the client's records, whether it splits
+			// them is unknown, not a BEAST vulnerability.
+			d.BEASTVuln = st.AbleToDetectNMinusOneSplitting && !st.NMinusOneRecordSplittingDetected
+			d.AbleToDetectNMinusOneSplitting = st.AbleToDetectNMinusOneSplitting
+		}
+		for _, r := range cs.brokenReasons() {
+			d.BrokenCipherSuites[s] = append(d.BrokenCipherSuites[s], r)
+		}
+		if cs.Cipher == "3DES_EDE" {
+			sweet32Seen = append(sweet32Seen, s)
+		} else if len(sweet32Seen) != 0 && !cs.Signaling && cs.MinVersion < tls.VersionTLS13 {
+			for _, seen := range sweet32Seen {
+				d.BrokenCipherSuites[seen] = append(d.BrokenCipherSuites[seen], sweet32Reason)
 			}
+			sweet32Seen = []string{}
+		}
+		d.SupportedCipherSuites = append(d.SupportedCipherSuites, s)
+	}
+	d.SessionTicketsSupported = st.SessionTicketsSupported
 
-			if strings.Contains(s, "_CBC") {
-				d.WeakCipherSuites[s] = append(d.WeakCipherSuites[s], cbcReason)
-			}
-			if strings.Contains(s, "TLS_RSA_WITH") {
-				d.WeakCipherSuites[s] = append(d.WeakCipherSuites[s], noEphemeralReason)
-			}
+	d.ClientHello = st.ClientHello
+	d.ClientHelloExtensions = make([]clientHelloExtension, 0, len(st.ClientHelloExtensions))
+	for _, ext := range st.ClientHelloExtensions {
+		d.ClientHelloExtensions = append(d.ClientHelloExtensions, clientHelloExtension{
+			Type:   ext.Type,
+			Length: len(ext.Data),
+			Data:   ext.Data,
+		})
+	}
+	d.JA3, d.JA3Hash = ja3(&st)
+	d.JA4 = ja4(&st)
+	d.IdentifiedClient = fdb.identify(d.JA4, d.JA3Hash)
+	d.GREASE = pullGREASEInfo(&st)
+	d.ECH = pullECHInfo(&st)
 
-			if cbcSuites[ci] && st.Version <= tls.VersionTLS10 {
-				d.BEASTVuln = !st.NMinusOneRecordSplittingDetected
-				d.AbleToDetectNMinusOneSplitting = st.AbleToDetectNMinusOneSplitting
-			}
-			if fewBitCipherSuites[s] {
-				d.BrokenCipherSuites[s] = append(d.BrokenCipherSuites[s], fewBitReason)
-			}
-			if nullCipherSuites[s] {
-				d.BrokenCipherSuites[s] = append(d.BrokenCipherSuites[s], nullReason)
-			}
-			if nullAuthCipherSuites[s] {
-				d.BrokenCipherSuites[s] = append(d.BrokenCipherSuites[s], nullAuthReason)
-			}
-			if rc4CipherSuites[s] {
-				d.BrokenCipherSuites[s] = append(d.BrokenCipherSuites[s], rc4Reason)
+	d.OfferedGroups = groupNames(st.ClientCurves)
+	d.KeyShareGroups = groupNames(st.ClientKeyShareGroups)
+	d.Negotiated = pullNegotiatedInfo(&st)
+	switch {
+	case st.DHGroupBits == 0:
+	case st.DHGroupBits < 1024:
+		d.LogjamVuln = true
+		d.DHGroupReason = logjamReason
+	case st.DHGroupBits < 2048:
+		d.WeakDHGroupAccepted = true
+		d.DHGroupReason = weakDHEReason
+	}
+	d.SupportedGroups = namedGroups(st.ClientCurves)
+	d.PointFormats = pointFormatNames(st.ClientPointFormats)
+	for _, g := range d.SupportedGroups {
+		if reasons := weakGroupReasons(tls.CurveID(g.ID)); len(reasons) != 0 {
+			d.WeakGroups[g.Name] = reasons
+		}
+		if g.SecurityLevel == "low" {
+			d.LowSecurityGroupSupported = true
+		}
+	}
+	// Key shares must also be listed in supported_groups, so checking the
+	// offered groups is enough.
+	for _, g := range st.ClientCurves {
+		if postQuantumGroups[g] {
+			d.PostQuantumReady = true
+		}
+	}
+
+	d.ExtendedMasterSecretSupported = st.ClientExtendedMasterSecret
+	d.EncryptThenMACSupported = st.ClientEncryptThenMAC
+	d.EmptyRenegotiationInfoSCSV = st.ClientRenegotiationSCSV
+	d.SecureRenegotiationSupported = st.ClientRenegotiationInfo || st.ClientRenegotiationSCSV
+	if st.ClientFallbackSCSV {
+		d.FallbackSCSVSent = true
+		d.FallbackSCSVReason = fallbackSCSVReason
+	}
+
+	d.SignatureAlgorithms = signatureSchemeNames(st.ClientSignatureAlgorithms)
+	d.SignatureAlgorithmsCert = signatureSchemeNames(st.ClientSignatureAlgorithmsCert)
+	for _, ss := range [][]tls.SignatureScheme{st.ClientSignatureAlgorithms, st.ClientSignatureAlgorithmsCert} {
+		for _, sa := range ss {
+			name := signatureSchemeName(sa)
+			if _, seen := d.WeakSignatureAlgorithms[name]; seen {
+				continue
 			}
-			if sweet32CipherSuites[s] {
-				sweet32Seen = append(sweet32Seen, s)
-			} else if len(sweet32Seen) != 0 && !metaCipherSuites[ci] && !tls13Suites[ci] {
-				for _, seen := range sweet32Seen {
-					d.BrokenCipherSuites[seen] = append(d.BrokenCipherSuites[seen], sweet32Reason)
+			if reasons := weakSignatureReasons(sa); len(reasons) != 0 {
+				d.WeakSignatureAlgorithms[name] = reasons
+				if reasons[0] == md5SigReason {
+					d.md5SigSupported = true
 				}
-				sweet32Seen = []string{}
-			}
-		} else {
-			w, found := weirdNSSSuites[ci]
-			if !found {
-				d.UnknownCipherSuiteSupported = true
-				s = fmt.Sprintf("An unknown cipher suite: %#04x", ci)
-			} else {
-				s = w
-				// The weirdNSSSuites cipher list also has DES encryption, so return the reason as insufficient bits.
-				d.BrokenCipherSuites[s] = append(d.BrokenCipherSuites[s], fewBitReason)
 			}
 		}
-		d.SupportedCipherSuites = append(d.SupportedCipherSuites, s)
 	}
-	d.SessionTicketsSupported = st.SessionTicketsSupported
 
 	for _, cm := range st.CompressionMethods {
 		if cm != 0x0 {
@@ -148,18 +249,8 @@ func pullClientInfo(c *conn) *clientInfo {
 			break
 		}
 	}
-	vers := st.Version
-	d.TLSVersion = actualSupportedVersions[vers]
-
-	// Check TLS 1.3's supported_versions extension for the actual TLS version
-	// if it was passed in.
-	for _, v := range st.SupportedVersions {
-		maybeStr, found := actualSupportedVersions[v]
-		if found && v > vers {
-			vers = v
-			d.TLSVersion = maybeStr
-		}
-	}
+	d.version = st.Version
+	d.TLSVersion = actualSupportedVersions[d.version]
 	if d.TLSVersion == "" {
 		d.TLSVersion = "an unknown version of SSL/TLS"
 	} else {
@@ -168,21 +259,8 @@ func pullClientInfo(c *conn) *clientInfo {
 		}
 	}
 
-	d.Rating = good
-	d.RatingScore = good_score
-
-	if !d.EphemeralKeysSupported || vers == tls.VersionTLS12 || !d.SessionTicketsSupported {
-		d.Rating = improvable
-		d.RatingScore = improvable_score
-	}
-
-	if d.TLSCompressionSupported ||
-		d.UnknownCipherSuiteSupported ||
-		d.BEASTVuln ||
-		len(d.BrokenCipherSuites) != 0 ||
-		vers <= tls.VersionTLS11 {
-		d.Rating = bad
-		d.RatingScore = bad_score
-	}
+	d.Vulnerabilities = findVulnerabilities(d, &st)
+	rp.rate(d)
+	d.Score = scoreClient(d, &st)
 	return d
 }
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// complianceProfile is a set of requirements from a published TLS
+// configuration standard. The standards are written for servers, so a client
+// complies if it negotiated what the standard allows and offered nothing it
+// forbids.
+type complianceProfile struct {
+	ID           string
+	Name         string
+	Requirements []complianceRequirement
+}
+
+type complianceRequirement struct {
+	ID          string
+	Description string
+
+	fails func(d *clientInfo, st *tls.ConnectionState) bool
+}
+
+type complianceResult struct {
+	Name                string                 `json:"name"`
+	Pass                bool                   `json:"pass"`
+	FailingRequirements []failedRequirementRef `json:"failing_requirements"`
+}
+
+type failedRequirementRef struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+var (
+	// NIST SP 800-52r2 section 3.3.1 and Mozilla's recommended
+	// configurations, as IANA suite IDs.
+	nistTLS13Suites = map[uint16]bool{
+		tls.TLS_AES_128_GCM_SHA256: true,
+		tls.TLS_AES_256_GCM_SHA384: true,
+		0x1304:                     true, // TLS_AES_128_CCM_SHA256
+		0x1305:                     true, // TLS_AES_128_CCM_8_SHA256
+	}
+	mozillaModernSuites = map[uint16]bool{
+		tls.TLS_AES_128_GCM_SHA256:       true,
+		tls.TLS_AES_256_GCM_SHA384:       true,
+		tls.TLS_CHACHA20_POLY1305_SHA256: true,
+	}
+	mozillaIntermediateSuites = map[uint16]bool{
+		tls.TLS_AES_128_GCM_SHA256:                    true,
+		tls.TLS_AES_256_GCM_SHA384:                    true,
+		tls.TLS_CHACHA20_POLY1305_SHA256:              true,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256:   true,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:     true,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384:   true,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:     true,
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305:    true,
+		tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305:      true,
+		tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256:       true,
+		tls.TLS_DHE_RSA_WITH_AES_256_GCM_SHA384:       true,
+		tls.TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256: true,
+	}
+
+	nistGroups    = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+	mozillaGroups = []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384}
+)
+
+var complianceProfiles = []*complianceProfile{
+	{
+		ID:   "nist-sp-800-52r2",
+		Name: "NIST SP 800-52 Revision 2",
+		Requirements: []complianceRequirement{
+			{
+				ID:          "tls_1_2_or_newer",
+				Description: "TLS 1.2 or newer must be negotiated.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return st.Version < tls.VersionTLS12 },
+			},
+			{
+				ID:          "tls_1_3_supported",
+				Description: "TLS 1.3 must be supported.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return maxOfferedVersion(st) < tls.VersionTLS13 },
+			},
+			{
+				ID:          "approved_cipher_suite",
+				Description: "The negotiated cipher suite must use AES with GCM, CCM or CBC, and ephemeral keys before TLS 1.3.",
+				fails: func(d *clientInfo, st *tls.ConnectionState) bool {
+					if st.Version >= tls.VersionTLS13 {
+						return !nistTLS13Suites[st.CipherSuite]
+					}
+					cs := cipherSuites[st.CipherSuite]
+					return cs.Cipher != "AES" || (cs.KeyExchange != "ECDHE" && cs.KeyExchange != "DHE")
+				},
+			},
+			{
+				ID:          "no_broken_cipher_suites",
+				Description: "Cipher suites with NULL, export grade, anonymous, RC4 or 3DES encryption must not be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return offersSuite(st, isDisallowedSuite) },
+			},
+			{
+				ID:          "approved_groups",
+				Description: "One of the NIST curves P-256, P-384 or P-521 must be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return !offersGroup(st, nistGroups) },
+			},
+			{
+				ID:          "extended_master_secret",
+				Description: "The extended_master_secret extension must be supported.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return !d.ExtendedMasterSecretSupported },
+			},
+			{
+				ID:          "secure_renegotiation",
+				Description: "Secure renegotiation must be supported.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return !d.SecureRenegotiationSupported },
+			},
+			{
+				ID:          "no_tls_compression",
+				Description: "TLS compression must not be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return d.TLSCompressionSupported },
+			},
+		},
+	},
+	{
+		ID:   "pci-dss",
+		Name: "PCI DSS v4.0",
+		Requirements: []complianceRequirement{
+			{
+				ID:          "no_early_tls",
+				Description: "SSL and early TLS (1.0 and 1.1) must not be negotiated.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return st.Version < tls.VersionTLS12 },
+			},
+			{
+				ID:          "strong_cryptography",
+				Description: "Only cipher suites with strong cryptography may be offered, so none with NULL, export grade, anonymous, RC4, DES or 3DES encryption.",
+				fails: func(d *clientInfo, st *tls.ConnectionState) bool {
+					return len(d.BrokenCipherSuites) != 0 || offersSuite(st, isDisallowedSuite)
+				},
+			},
+			{
+				ID:          "strong_negotiated_cipher_suite",
+				Description: "The negotiated cipher suite must use at least 128-bit encryption.",
+				fails: func(d *clientInfo, st *tls.ConnectionState) bool {
+					cs, ok := cipherSuites[st.CipherSuite]
+					return !ok || cs.Cipher == "NULL" || cs.KeyBits < 128
+				},
+			},
+			{
+				ID:          "no_md5_signatures",
+				Description: "Signature algorithms using MD5 must not be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return d.md5SigSupported },
+			},
+			{
+				ID:          "no_tls_compression",
+				Description: "TLS compression must not be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return d.TLSCompressionSupported },
+			},
+		},
+	},
+	{
+		ID:   "mozilla-modern",
+		Name: "Mozilla Modern (v5.7)",
+		Requirements: []complianceRequirement{
+			{
+				ID:          "tls_1_3",
+				Description: "TLS 1.3 must be negotiated.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return st.Version < tls.VersionTLS13 },
+			},
+			{
+				ID:          "modern_cipher_suite",
+				Description: "One of TLS_AES_128_GCM_SHA256, TLS_AES_256_GCM_SHA384 or TLS_CHACHA20_POLY1305_SHA256 must be offered.",
+				fails: func(d *clientInfo, st *tls.ConnectionState) bool {
+					return !offersSuite(st, func(id uint16) bool { return mozillaModernSuites[id] })
+				},
+			},
+			{
+				ID:          "modern_groups",
+				Description: "One of X25519, P-256 or P-384 must be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return !offersGroup(st, mozillaGroups) },
+			},
+		},
+	},
+	{
+		ID:   "mozilla-intermediate",
+		Name: "Mozilla Intermediate (v5.7)",
+		Requirements: []complianceRequirement{
+			{
+				ID:          "tls_1_2_or_newer",
+				Description: "TLS 1.2 or newer must be negotiated.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return st.Version < tls.VersionTLS12 },
+			},
+			{
+				ID:          "intermediate_cipher_suite",
+				Description: "The negotiated cipher suite must be an AEAD suite with ephemeral keys from the intermediate configuration.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return !mozillaIntermediateSuites[st.CipherSuite] },
+			},
+			{
+				ID:          "intermediate_groups",
+				Description: "One of X25519, P-256 or P-384 must be offered.",
+				fails:       func(d *clientInfo, st *tls.ConnectionState) bool { return !offersGroup(st, mozillaGroups) },
+			},
+			{
+				ID:          "dh_2048_or_larger",
+				Description: "Diffie-Hellman parameters smaller than 2048 bits must not be accepted.",
+				fails: func(d *clientInfo, st *tls.ConnectionState) bool {
+					return d.LogjamVuln || d.WeakDHGroupAccepted
+				},
+			},
+		},
+	},
+}
+
+// complianceProfilesByID is complianceProfiles keyed by ID.
+var complianceProfilesByID = func() map[string]*complianceProfile {
+	m := make(map[string]*complianceProfile, len(complianceProfiles))
+	for _, p := range complianceProfiles {
+		m[p.ID] = p
+	}
+	return m
+}()
+
+// parseComplianceProfiles parses the API's comma-separated profile
+// parameter. An empty parameter selects every profile.
+func parseComplianceProfiles(s string) ([]*complianceProfile, error) {
+	if s == "" {
+		return complianceProfiles, nil
+	}
+	var profiles []*complianceProfile
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(s, ",") {
+		id = strings.TrimSpace(id)
+		p, ok := complianceProfilesByID[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown compliance profile %#v", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+// checkCompliance evaluates each profile against the client.
+func checkCompliance(d *clientInfo, st *tls.ConnectionState, profiles []*complianceProfile) map[string]complianceResult {
+	results := make(map[string]complianceResult, len(profiles))
+	for _, p := range profiles {
+		res := complianceResult{
+			Name:                p.Name,
+			Pass:                true,
+			FailingRequirements: []failedRequirementRef{},
+		}
+		for _, req := range p.Requirements {
+			if req.fails(d, st) {
+				res.Pass = false
+				res.FailingRequirements = append(res.FailingRequirements, failedRequirementRef{
+					ID:          req.ID,
+					Description: req.Description,
+				})
+			}
+		}
+		results[p.ID] = res
+	}
+	return results
+}
+
+// maxOfferedVersion returns the highest version the client offered, from
+// supported_versions if it was sent.
+func maxOfferedVersion(st *tls.ConnectionState) uint16 {
+	if len(st.SupportedVersions) == 0 {
+		return st.ClientVersion
+	}
+	var highest uint16
+	for _, v := range st.SupportedVersions {
+		if !isGREASE(v) && v > highest {
+			highest = v
+		}
+	}
+	return highest
+}
+
+func offersSuite(st *tls.ConnectionState, match func(id uint16) bool) bool {
+	for _, id := range st.ClientCipherSuites {
+		if match(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func offersGroup(st *tls.ConnectionState, groups []tls.CurveID) bool {
+	for _, c := range st.ClientCurves {
+		for _, g := range groups {
+			if c == g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDisallowedSuite reports whether SP 800-52r2 and PCI DSS forbid offering
+// the suite. Unlike BrokenCipherSuites, that includes 3DES even when it's
+// only offered last, out of reach of Sweet32.
+func isDisallowedSuite(id uint16) bool {
+	cs, ok := cipherSuites[id]
+	if !ok || cs.Signaling {
+		return false
+	}
+	return cs.Cipher == "NULL" || cs.Export || cs.Auth == "anon" || cs.Cipher == "RC4" || cs.Cipher == "3DES_EDE"
+}
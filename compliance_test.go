@@ -0,0 +1,90 @@
+package main
+
+import (
+	stdtls "crypto/tls"
+	"reflect"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func failingIDs(res complianceResult) []string {
+	ids := []string{}
+	for _, f := range res.FailingRequirements {
+		ids = append(ids, f.ID)
+	}
+	return ids
+}
+
+func TestComplianceModernClient(t *testing.T) {
+	c := connectStdlib(t, &stdtls.Config{CipherSuites: []uint16{stdtls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	st := c.ConnectionState()
+	results := checkCompliance(d, &st, complianceProfiles)
+	if len(results) != len(complianceProfiles) {
+		t.Fatalf("want %d profiles, got %d", len(complianceProfiles), len(results))
+	}
+	for id, res := range results {
+		if !res.Pass {
+			t.Errorf("%s: want pass, got failing requirements %v", id, failingIDs(res))
+		}
+	}
+}
+
+func TestComplianceOldClient(t *testing.T) {
+	c := connect(t, &tls.Config{
+		MaxVersion:   tls.VersionTLS10,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA},
+	})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	st := c.ConnectionState()
+	results := checkCompliance(d, &st, complianceProfiles)
+	want := map[string][]string{
+		"nist-sp-800-52r2":     {"tls_1_2_or_newer", "tls_1_3_supported", "no_broken_cipher_suites", "extended_master_secret"},
+		"pci-dss":              {"no_early_tls", "strong_cryptography"},
+		"mozilla-modern":       {"tls_1_3", "modern_cipher_suite"},
+		"mozilla-intermediate": {"tls_1_2_or_newer", "intermediate_cipher_suite"},
+	}
+	for id, wantIDs := range want {
+		res := results[id]
+		if res.Pass {
+			t.Errorf("%s: want fail, got pass", id)
+		}
+		if got := failingIDs(res); !reflect.DeepEqual(got, wantIDs) {
+			t.Errorf("%s: failing requirements: want %v, got %v", id, wantIDs, got)
+		}
+	}
+}
+
+func TestParseComplianceProfiles(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"", []string{"nist-sp-800-52r2", "pci-dss", "mozilla-modern", "mozilla-intermediate"}, false},
+		{"pci-dss", []string{"pci-dss"}, false},
+		{"mozilla-modern, pci-dss,mozilla-modern", []string{"mozilla-modern", "pci-dss"}, false},
+		{"pci-dss,hipaa", nil, true},
+	}
+	for _, tt := range tests {
+		profiles, err := parseComplianceProfiles(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%#v: want error, got nil", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%#v: unexpected error: %s", tt.in, err)
+			continue
+		}
+		var got []string
+		for _, p := range profiles {
+			got = append(got, p.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%#v: want %v, got %v", tt.in, tt.want, got)
+		}
+	}
+}
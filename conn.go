@@ -1,6 +1,7 @@
 package main
 
 import (
+	stdtls "crypto/tls"
 	"errors"
 	"expvar"
 	"io"
@@ -14,12 +15,20 @@ import (
 var (
 	_              net.Listener = &listener{}
 	_              net.Conn     = &conn{}
+	_              tlsConn      = &tls.Conn{}
+	_              tlsConn      = &peekConn{}
 	errTLSConnConv              = errors.New("Unable to convert net.Conn to tls.Conn")
 )
 
+// listener wraps the TLS connections accepted by its net.Listener in conns.
+// If stdlibConf is nil, the net.Listener must be a tls110 one. Otherwise, it
+// must accept plain connections, which are served with crypto/tls or, for
+// clients crypto/tls can't serve, tls110 with legacyConf.
 type listener struct {
 	net.Listener
 	*handshakeStats
+	stdlibConf *stdtls.Config
+	legacyConf *tls.Config
 }
 
 type handshakeStats struct {
@@ -58,25 +67,49 @@ func newListener(nl net.Listener, ns *expvar.Map) *listener {
 	return lis
 }
 
+// newStdlibListener is like newListener but serves the plain connections
+// accepted by nl with crypto/tls configured like conf, falling back to tls110
+// with conf itself (see peekConn).
+func newStdlibListener(nl net.Listener, conf *tls.Config, ns *expvar.Map) *listener {
+	lis := newListener(nl, ns)
+	lis.stdlibConf = makeStdlibTLSConfig(conf)
+	lis.legacyConf = conf
+	return lis
+}
+
 func (l *listener) Accept() (net.Conn, error) {
 	c, err := l.Listener.Accept()
 	if err != nil {
 		return c, err
 
 	}
-	tlsConn, ok := c.(*tls.Conn)
+	if l.stdlibConf != nil {
+		return &conn{
+			tlsConn:        newPeekConn(c, l.stdlibConf, l.legacyConf),
+			handshakeStats: l.handshakeStats,
+		}, nil
+	}
+	tc, ok := c.(*tls.Conn)
 	if !ok {
 		c.Close()
 		return nil, errTLSConnConv
 	}
 	return &conn{
-		Conn:           tlsConn,
+		tlsConn:        tc,
 		handshakeStats: l.handshakeStats,
 	}, nil
 }
 
+// tlsConn is the server side of a TLS connection, from tls110 or, with
+// peekConn, crypto/tls.
+type tlsConn interface {
+	net.Conn
+	Handshake() error
+	ConnectionState() tls.ConnectionState
+}
+
 type conn struct {
-	*tls.Conn
+	tlsConn
 	handshakeCounted int32
 	*handshakeStats
 }
@@ -86,7 +119,7 @@ func (c *conn) Read(b []byte) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	return c.Conn.Read(b)
+	return c.tlsConn.Read(b)
 }
 
 func (c *conn) Write(b []byte) (int, error) {
@@ -94,7 +127,7 @@ func (c *conn) Write(b []byte) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	return c.Conn.Write(b)
+	return c.tlsConn.Write(b)
 }
 
 // This, unfortunately, means we take two uncontended locks on every read and
@@ -105,7 +138,7 @@ func (c *conn) handshake() error {
 		return nil
 	}
 
-	err := c.Conn.Handshake()
+	err := c.tlsConn.Handshake()
 	if err != nil {
 		return err
 	}
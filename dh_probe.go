@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+var (
+	logjamReason  = "The client accepted Diffie-Hellman parameters smaller than 1024 bits, which an attacker can break to read or alter the connection, as in the Logjam attack."
+	weakDHEReason = "The client accepted 1024-bit Diffie-Hellman parameters, which are within reach of well-funded attackers."
+)
+
+// dheSuites are the cipher suites offered by the DH probe listeners, so that
+// every handshake with them uses DHE.
+var dheSuites = []uint16{
+	tls.TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_DHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_DHE_RSA_WITH_AES_128_CBC_SHA256,
+	tls.TLS_DHE_RSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_DHE_RSA_WITH_AES_256_CBC_SHA,
+	tls.TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA,
+}
+
+// dhProbe is an extra HTTPS listener that only does DHE with a group of one
+// size. A client that can load a page from it accepts groups that size.
+type dhProbe struct {
+	bits int
+	addr string
+}
+
+// parseDHProbes parses a comma-separated list of bits=addr pairs, like
+// "512=:10512,1024=:11024".
+func parseDHProbes(s string) ([]dhProbe, error) {
+	var probes []dhProbe
+	if s == "" {
+		return probes, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("DH probe %#v is not of the form bits=addr", pair)
+		}
+		bits, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("DH probe %#v has a bad group size: %s", pair, err)
+		}
+		switch bits {
+		case 512, 768, 1024, 2048:
+		default:
+			return nil, fmt.Errorf("DH probe %#v has an unsupported group size; use 512, 768, 1024 or 2048", pair)
+		}
+		probes = append(probes, dhProbe{bits: bits, addr: parts[1]})
+	}
+	return probes, nil
+}
+
+// makeDHProbeConfig returns a copy of base that only negotiates DHE with a
+// group of the given size.
+func makeDHProbeConfig(base *tls.Config, bits int) *tls.Config {
+	conf := base.Clone()
+	conf.DHGroupBits = bits
+	conf.CipherSuites = dheSuites
+	// TLS 1.3 has no DHE with custom groups.
+	conf.MaxVersion = tls.VersionTLS12
+	return conf
+}
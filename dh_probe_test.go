@@ -0,0 +1,22 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDHProbes(t *testing.T) {
+	got, err := parseDHProbes("512=:10512,1024=localhost:11024")
+	if err != nil {
+		t.Fatalf("parseDHProbes: %s", err)
+	}
+	want := []dhProbe{{512, ":10512"}, {1024, "localhost:11024"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDHProbes: want %v, got %v", want, got)
+	}
+	for _, s := range []string{"512", "x=:1", "333=:1"} {
+		if _, err := parseDHProbes(s); err == nil {
+			t.Errorf("parseDHProbes(%q) didn't fail", s)
+		}
+	}
+}
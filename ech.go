@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// hpkeKDFs and hpkeAEADs map the HPKE identifiers used in ECH cipher suites
+// to their names in the IANA HPKE registry.
+// https://www.iana.org/assignments/hpke/hpke.xhtml
+var hpkeKDFs = map[uint16]string{
+	0x0001: "HKDF-SHA256",
+	0x0002: "HKDF-SHA384",
+	0x0003: "HKDF-SHA512",
+}
+
+var hpkeAEADs = map[uint16]string{
+	0x0001: "AES-128-GCM",
+	0x0002: "AES-256-GCM",
+	0x0003: "ChaCha20Poly1305",
+	0xffff: "Export-only",
+}
+
+// echInfo describes the client's encrypted_client_hello extension.
+type echInfo struct {
+	Supported     bool   `json:"supported"`      // the extension was sent, real or GREASE
+	Type          string `json:"type,omitempty"` // "outer" or "inner"
+	GREASE        bool   `json:"grease"`
+	ConfigID      uint8  `json:"config_id"`
+	KDF           string `json:"kdf,omitempty"`
+	AEAD          string `json:"aead,omitempty"`
+	EncLength     int    `json:"enc_length"`
+	PayloadLength int    `json:"payload_length"`
+}
+
+// pullECHInfo reports the ECH extension in st.
+//
+// howsmyssl publishes no ECHConfig, so an outer extension can only be GREASE
+// or meant for a different client-facing server; either way the client
+// wasn't hiding its ClientHello from us, and it's reported as GREASE. Real
+// and GREASE ECH are built to look alike on the wire, which is the point.
+// An inner extension must only appear inside the encrypted ClientHelloInner,
+// so seeing one here means the client is broken.
+func pullECHInfo(st *tls.ConnectionState) echInfo {
+	ech := st.ClientEncryptedClientHello
+	if ech == nil {
+		return echInfo{}
+	}
+	if ech.Type == tls.ECHClientHelloInner {
+		return echInfo{Supported: true, Type: "inner"}
+	}
+	return echInfo{
+		Supported:     true,
+		Type:          "outer",
+		GREASE:        true,
+		ConfigID:      ech.ConfigID,
+		KDF:           hpkeName(hpkeKDFs, ech.KDF, "KDF"),
+		AEAD:          hpkeName(hpkeAEADs, ech.AEAD, "AEAD"),
+		EncLength:     ech.EncLength,
+		PayloadLength: ech.PayloadLength,
+	}
+}
+
+func hpkeName(names map[uint16]string, id uint16, kind string) string {
+	if s, ok := names[id]; ok {
+		return s
+	}
+	return fmt.Sprintf("An unknown %s: %#04x", kind, id)
+}
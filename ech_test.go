@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func TestPullECHInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		ech  *tls.EncryptedClientHello
+		want echInfo
+	}{
+		{"not sent", nil, echInfo{}},
+		{
+			"outer",
+			&tls.EncryptedClientHello{Type: tls.ECHClientHelloOuter, KDF: 1, AEAD: 3, ConfigID: 0x9c, EncLength: 32, PayloadLength: 208},
+			echInfo{Supported: true, Type: "outer", GREASE: true, ConfigID: 0x9c, KDF: "HKDF-SHA256", AEAD: "ChaCha20Poly1305", EncLength: 32, PayloadLength: 208},
+		},
+		{
+			"outer with unknown HPKE suite",
+			&tls.EncryptedClientHello{Type: tls.ECHClientHelloOuter, KDF: 0x99, AEAD: 1, EncLength: 32, PayloadLength: 1},
+			echInfo{Supported: true, Type: "outer", GREASE: true, KDF: "An unknown KDF: 0x0099", AEAD: "AES-128-GCM", EncLength: 32, PayloadLength: 1},
+		},
+		{"inner", &tls.EncryptedClientHello{Type: tls.ECHClientHelloInner}, echInfo{Supported: true, Type: "inner"}},
+	}
+	for _, tt := range tests {
+		st := &tls.ConnectionState{ClientEncryptedClientHello: tt.ech}
+		if got := pullECHInfo(st); got != tt.want {
+			t.Errorf("%s: want %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
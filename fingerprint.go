@@ -0,0 +1,189 @@
+package main
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+const (
+	extensionServerName          uint16 = 0
+	extensionALPN                uint16 = 16
+	extensionSignatureAlgorithms uint16 = 13
+	extensionSupportedVersions   uint16 = 43
+)
+
+// ja3 returns the JA3 fingerprint string of the client's ClientHello and its
+// MD5 hash in hex. See https://github.com/salesforce/ja3.
+func ja3(st *tls.ConnectionState) (string, string) {
+	var ciphers, exts, curves, points []string
+	for _, cs := range st.ClientCipherSuites {
+		if !isGREASE(cs) {
+			ciphers = append(ciphers, strconv.Itoa(int(cs)))
+		}
+	}
+	for _, ext := range st.ClientHelloExtensions {
+		if !isGREASE(ext.Type) {
+			exts = append(exts, strconv.Itoa(int(ext.Type)))
+		}
+	}
+	for _, c := range st.ClientCurves {
+		if !isGREASE(uint16(c)) {
+			curves = append(curves, strconv.Itoa(int(c)))
+		}
+	}
+	for _, p := range st.ClientPointFormats {
+		points = append(points, strconv.Itoa(int(p)))
+	}
+
+	s := strings.Join([]string{
+		strconv.Itoa(int(st.ClientVersion)),
+		strings.Join(ciphers, "-"),
+		strings.Join(exts, "-"),
+		strings.Join(curves, "-"),
+		strings.Join(points, "-"),
+	}, ",")
+	sum := md5.Sum([]byte(s))
+	return s, hex.EncodeToString(sum[:])
+}
+
+// ja4 returns the JA4 TLS client fingerprint of the client's ClientHello.
+// See https://github.com/FoxIO-LLC/ja4/blob/main/technical_details/JA4.md.
+func ja4(st *tls.ConnectionState) string {
+	var ciphers []string
+	for _, cs := range st.ClientCipherSuites {
+		if !isGREASE(cs) {
+			ciphers = append(ciphers, fmt.Sprintf("%04x", cs))
+		}
+	}
+
+	var exts []string
+	numExts := 0
+	hasSNI := false
+	vers := st.ClientVersion
+	var sigAlgs []string
+	for _, ext := range st.ClientHelloExtensions {
+		if isGREASE(ext.Type) {
+			continue
+		}
+		numExts++
+		switch ext.Type {
+		case extensionServerName:
+			hasSNI = true
+		case extensionALPN:
+		case extensionSupportedVersions:
+			if v := highestSupportedVersion(ext.Data); v != 0 {
+				vers = v
+			}
+			exts = append(exts, fmt.Sprintf("%04x", ext.Type))
+		case extensionSignatureAlgorithms:
+			for _, sa := range st.ClientSignatureAlgorithms {
+				if !isGREASE(uint16(sa)) {
+					sigAlgs = append(sigAlgs, fmt.Sprintf("%04x", uint16(sa)))
+				}
+			}
+			exts = append(exts, fmt.Sprintf("%04x", ext.Type))
+		default:
+			exts = append(exts, fmt.Sprintf("%04x", ext.Type))
+		}
+	}
+
+	sni := "i"
+	if hasSNI {
+		sni = "d"
+	}
+	alpn := "00"
+	if len(st.ClientALPNProtocols) > 0 {
+		alpn = ja4ALPN(st.ClientALPNProtocols[0])
+	}
+	a := fmt.Sprintf("t%s%s%02d%02d%s", ja4Version(vers), sni, min99(len(ciphers)), min99(numExts), alpn)
+
+	sort.Strings(ciphers)
+	b := ja4Hash(strings.Join(ciphers, ","))
+
+	sort.Strings(exts)
+	c := strings.Join(exts, ",")
+	if len(sigAlgs) > 0 {
+		c += "_" + strings.Join(sigAlgs, ",")
+	}
+	if len(exts) == 0 {
+		c = ""
+	}
+	return a + "_" + b + "_" + ja4Hash(c)
+}
+
+// highestSupportedVersion returns the highest non-GREASE version in the body
+// of a supported_versions extension, or 0 if there is none.
+func highestSupportedVersion(data []byte) uint16 {
+	if len(data) < 1 || int(data[0]) != len(data)-1 {
+		return 0
+	}
+	var highest uint16
+	for d := data[1:]; len(d) >= 2; d = d[2:] {
+		v := uint16(d[0])<<8 | uint16(d[1])
+		if !isGREASE(v) && v > highest {
+			highest = v
+		}
+	}
+	return highest
+}
+
+func ja4Version(vers uint16) string {
+	switch vers {
+	case tls.VersionTLS13:
+		return "13"
+	case tls.VersionTLS12:
+		return "12"
+	case tls.VersionTLS11:
+		return "11"
+	case tls.VersionTLS10:
+		return "10"
+	case tls.VersionSSL30:
+		return "s3"
+	case 0x0002:
+		return "s2"
+	}
+	return "00"
+}
+
+// ja4ALPN returns the first and last characters of the first ALPN value, or
+// the first and last hex digits of it if either character isn't
+// alphanumeric.
+func ja4ALPN(proto string) string {
+	if proto == "" {
+		return "00"
+	}
+	first, last := proto[0], proto[len(proto)-1]
+	if isAlphanumeric(first) && isAlphanumeric(last) {
+		return string([]byte{first, last})
+	}
+	h := hex.EncodeToString([]byte(proto))
+	return string([]byte{h[0], h[len(h)-1]})
+}
+
+func isAlphanumeric(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// ja4Hash returns the first 12 hex characters of the SHA-256 of s, or all
+// zeros for an empty list.
+func ja4Hash(s string) string {
+	if s == "" {
+		return "000000000000"
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func min99(n int) int {
+	if n > 99 {
+		return 99
+	}
+	return n
+}
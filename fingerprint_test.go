@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// testClientHelloState returns a ConnectionState shaped like a modern
+// browser's ClientHello, GREASE included.
+func testClientHelloState(exts []uint16) *tls.ConnectionState {
+	st := &tls.ConnectionState{
+		ClientVersion:             tls.VersionTLS12,
+		ClientCipherSuites:        []uint16{0x1a1a, tls.TLS_AES_128_GCM_SHA256, tls.TLS_AES_256_GCM_SHA384, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA},
+		ClientCurves:              []tls.CurveID{0x4a4a, tls.X25519, tls.CurveP256, tls.CurveP384},
+		ClientPointFormats:        []uint8{0},
+		ClientALPNProtocols:       []string{"h2", "http/1.1"},
+		ClientSignatureAlgorithms: []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256, tls.PSSWithSHA256, tls.PKCS1WithSHA256, tls.ECDSAWithP384AndSHA384},
+	}
+	for _, typ := range exts {
+		ext := tls.ClientHelloExtension{Type: typ}
+		if typ == extensionSupportedVersions {
+			ext.Data = []byte{6, 0x3a, 0x3a, 0x03, 0x04, 0x03, 0x03}
+		}
+		st.ClientHelloExtensions = append(st.ClientHelloExtensions, ext)
+	}
+	return st
+}
+
+func TestJA3(t *testing.T) {
+	st := testClientHelloState([]uint16{0x2a2a, 0, 23, 0xff01, 10, 11, 35, 16, 5, 13, 18, 51, 45, 43, 27, 21})
+	s, hash := ja3(st)
+	wantS := "771,4865-4866-49195-10,0-23-65281-10-11-35-16-5-13-18-51-45-43-27-21,29-23-24,0"
+	if s != wantS {
+		t.Errorf("ja3 string: want %q, got %q", wantS, s)
+	}
+	if want := "d8348aae3d11c40ee90a1fa3e37636c1"; hash != want {
+		t.Errorf("ja3 hash: want %s, got %s", want, hash)
+	}
+}
+
+func TestJA4(t *testing.T) {
+	tests := []struct {
+		name  string
+		st    *tls.ConnectionState
+		want  string
+		setup func(st *tls.ConnectionState)
+	}{
+		{
+			name: "TLS 1.3 with SNI and ALPN",
+			st:   testClientHelloState([]uint16{0x2a2a, 0, 23, 0xff01, 10, 11, 35, 16, 5, 13, 18, 51, 45, 43, 27, 21}),
+			want: "t13d0415h2_bdddf0899e80_f233a195bee5",
+		},
+		{
+			name: "TLS 1.2 without SNI, ALPN or signature algorithms",
+			st:   testClientHelloState([]uint16{0x2a2a, 23, 0xff01, 10, 11, 35, 5, 18, 51, 45, 27, 21}),
+			want: "t12i041100_bdddf0899e80_53945c64e71d",
+			setup: func(st *tls.ConnectionState) {
+				st.ClientALPNProtocols = nil
+				st.ClientSignatureAlgorithms = nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup(tt.st)
+			}
+			if got := ja4(tt.st); got != tt.want {
+				t.Errorf("ja4: want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestJA4ALPN(t *testing.T) {
+	tests := []struct {
+		proto string
+		want  string
+	}{
+		{"h2", "h2"},
+		{"http/1.1", "h1"},
+		{"h", "hh"},
+		{"\xab\xcd", "ad"},
+		{"", "00"},
+	}
+	for _, tt := range tests {
+		if got := ja4ALPN(tt.proto); got != tt.want {
+			t.Errorf("ja4ALPN(%q): want %q, got %q", tt.proto, tt.want, got)
+		}
+	}
+}
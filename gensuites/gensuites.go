@@ -0,0 +1,393 @@
+// gensuites generates the cipher suite registry in suites_gen.go from a copy
+// of the IANA TLS Cipher Suites registry. Update the registry with:
+//
+//	curl -s -o gensuites/tls-parameters-4.csv https://www.iana.org/assignments/tls-parameters/tls-parameters-4.csv
+//	go generate
+//
+// Each suite's key exchange, authentication, bulk cipher and MAC are parsed
+// from its name, so a suite whose name uses a new algorithm will make
+// gensuites fail until it's taught about it below.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var outFile = flag.String("o", "suites_gen.go", "file to write the generated registry to")
+
+// extraSuites are suites that aren't in the IANA registry but that clients
+// have been seen sending. parseAs is the registry style name they're parsed
+// as, when their own name doesn't follow it.
+var extraSuites = []struct {
+	id      uint16
+	name    string
+	parseAs string
+}{
+	// Asked for in #56. OpenSSL's GOST engine uses these.
+	{0x0081, "TLS_GOST2001-GOST89-GOST89", "TLS_GOSTR341001_WITH_28147_CNT_IMIT"},
+	{0xFF85, "TLS_GOST2012256-GOST89-GOST89", "TLS_GOSTR341112_256_WITH_28147_CNT_IMIT"},
+
+	// https://tools.ietf.org/html/draft-ietf-tls-56-bit-ciphersuites-01,
+	// plus two more discovered in the wild.
+	{0x0060, "TLS_RSA_EXPORT1024_WITH_RC4_56_MD5", ""},
+	{0x0061, "TLS_RSA_EXPORT1024_WITH_RC2_CBC_56_MD5", ""},
+	{0x0062, "TLS_RSA_EXPORT1024_WITH_DES_CBC_SHA", ""},
+	{0x0063, "TLS_DHE_DSS_EXPORT1024_WITH_DES_CBC_SHA", ""},
+	{0x0064, "TLS_RSA_EXPORT1024_WITH_RC4_56_SHA", ""},
+	{0x0065, "TLS_DHE_DSS_EXPORT1024_WITH_RC4_56_SHA", ""},
+	{0x0066, "TLS_DHE_DSS_WITH_RC4_128_SHA", ""}, // 128-bit RC4, not 56-bit
+
+	// ChaCha20-Poly1305 code points used before RFC 7905.
+	{0xCC13, "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256_OLD", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+	{0xCC14, "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256_OLD", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256"},
+	{0xCC15, "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256_OLD", "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+
+	// Chrome tested these quantum computer resistant cipher suites. We, for
+	// now, assume they are safe.
+	{0x16B7, "TLS_CECPQ1_RSA_WITH_CHACHA20_POLY1305_SHA256", ""},
+	{0x16B8, "TLS_CECPQ1_ECDSA_WITH_CHACHA20_POLY1305_SHA256", ""},
+	{0x16B9, "TLS_CECPQ1_RSA_WITH_AES_256_GCM_SHA384", ""},
+	{0x16BA, "TLS_CECPQ1_ECDSA_WITH_AES_256_GCM_SHA384", ""},
+
+	// Obsolete cipher suites in NSS that were meant to die with SSL 3.0 but
+	// 0xFEFF is still emitted by Firefox 25.0. Discussed here:
+	// https://groups.google.com/forum/#!topic/mozilla.dev.tech.crypto/oWk0FkKsek4
+	// and
+	// http://www-archive.mozilla.org/projects/security/pki/nss/ssl/fips-ssl-ciphersuites.html
+	{0xFEFE, "SSL_RSA_FIPS_WITH_DES_CBC_SHA", "TLS_RSA_WITH_DES_CBC_SHA"},
+	{0xFEFF, "SSL_RSA_FIPS_WITH_3DES_EDE_CBC_SHA", "TLS_RSA_WITH_3DES_EDE_CBC_SHA"},
+}
+
+// keyExchanges maps the part of a suite name before "_WITH_" to its key
+// exchange and authentication algorithms.
+var keyExchanges = map[string][2]string{
+	"NULL":            {"NULL", "NULL"},
+	"RSA":             {"RSA", "RSA"},
+	"DH_DSS":          {"DH", "DSS"},
+	"DH_RSA":          {"DH", "RSA"},
+	"DHE_DSS":         {"DHE", "DSS"},
+	"DHE_RSA":         {"DHE", "RSA"},
+	"DH_anon":         {"DH", "anon"},
+	"KRB5":            {"KRB5", "KRB5"},
+	"PSK":             {"PSK", "PSK"},
+	"DHE_PSK":         {"DHE_PSK", "PSK"},
+	"PSK_DHE":         {"DHE_PSK", "PSK"},
+	"RSA_PSK":         {"RSA_PSK", "RSA"},
+	"ECDHE_PSK":       {"ECDHE_PSK", "PSK"},
+	"ECDH_ECDSA":      {"ECDH", "ECDSA"},
+	"ECDHE_ECDSA":     {"ECDHE", "ECDSA"},
+	"ECDH_RSA":        {"ECDH", "RSA"},
+	"ECDHE_RSA":       {"ECDHE", "RSA"},
+	"ECDH_anon":       {"ECDH", "anon"},
+	"SRP_SHA":         {"SRP", "SRP"},
+	"SRP_SHA_RSA":     {"SRP", "RSA"},
+	"SRP_SHA_DSS":     {"SRP", "DSS"},
+	"ECCPWD":          {"ECCPWD", "ECCPWD"},
+	"CECPQ1_RSA":      {"CECPQ1", "RSA"},
+	"CECPQ1_ECDSA":    {"CECPQ1", "ECDSA"},
+	"GOSTR341001":     {"GOSTR341001", "GOSTR341001"},
+	"GOSTR341112_256": {"GOSTR341112_256", "GOSTR341112_256"},
+}
+
+type bulkCipher struct {
+	cipher  string
+	mode    string
+	keyBits int
+	aead    bool
+}
+
+// bulkCiphers maps the part of a suite name between "_WITH_" and the MAC to
+// its bulk cipher.
+var bulkCiphers = map[string]bulkCipher{
+	"NULL":              {"NULL", "", 0, false},
+	"SHA256":            {"NULL", "", 0, false}, // TLS 1.3 integrity-only suites
+	"SHA384":            {"NULL", "", 0, false},
+	"RC4_40":            {"RC4", "stream", 40, false},
+	"RC4_56":            {"RC4", "stream", 56, false},
+	"RC4_128":           {"RC4", "stream", 128, false},
+	"RC2_CBC_40":        {"RC2", "CBC", 40, false},
+	"RC2_CBC_56":        {"RC2", "CBC", 56, false},
+	"IDEA_CBC":          {"IDEA", "CBC", 128, false},
+	"DES40_CBC":         {"DES", "CBC", 40, false},
+	"DES_CBC_40":        {"DES", "CBC", 40, false},
+	"DES_CBC":           {"DES", "CBC", 56, false},
+	"3DES_EDE_CBC":      {"3DES_EDE", "CBC", 168, false},
+	"SEED_CBC":          {"SEED", "CBC", 128, false},
+	"AES_128_CBC":       {"AES", "CBC", 128, false},
+	"AES_256_CBC":       {"AES", "CBC", 256, false},
+	"AES_128_GCM":       {"AES", "GCM", 128, true},
+	"AES_256_GCM":       {"AES", "GCM", 256, true},
+	"AES_128_CCM":       {"AES", "CCM", 128, true},
+	"AES_256_CCM":       {"AES", "CCM", 256, true},
+	"AES_128_CCM_8":     {"AES", "CCM_8", 128, true},
+	"AES_256_CCM_8":     {"AES", "CCM_8", 256, true},
+	"CAMELLIA_128_CBC":  {"CAMELLIA", "CBC", 128, false},
+	"CAMELLIA_256_CBC":  {"CAMELLIA", "CBC", 256, false},
+	"CAMELLIA_128_GCM":  {"CAMELLIA", "GCM", 128, true},
+	"CAMELLIA_256_GCM":  {"CAMELLIA", "GCM", 256, true},
+	"ARIA_128_CBC":      {"ARIA", "CBC", 128, false},
+	"ARIA_256_CBC":      {"ARIA", "CBC", 256, false},
+	"ARIA_128_GCM":      {"ARIA", "GCM", 128, true},
+	"ARIA_256_GCM":      {"ARIA", "GCM", 256, true},
+	"CHACHA20_POLY1305": {"CHACHA20", "POLY1305", 256, true},
+	"SM4_GCM":           {"SM4", "GCM", 128, true},
+	"SM4_CCM":           {"SM4", "CCM", 128, true},
+	"28147_CNT":         {"28147", "CNT", 256, false},
+	"KUZNYECHIK_CTR":    {"KUZNYECHIK", "CTR", 256, false},
+	"MAGMA_CTR":         {"MAGMA", "CTR", 256, false},
+	"KUZNYECHIK_MGM_L":  {"KUZNYECHIK", "MGM", 256, true},
+	"KUZNYECHIK_MGM_S":  {"KUZNYECHIK", "MGM", 256, true},
+	"MAGMA_MGM_L":       {"MAGMA", "MGM", 256, true},
+	"MAGMA_MGM_S":       {"MAGMA", "MGM", 256, true},
+}
+
+// macs are the suffixes a suite name can end in, longest first so that
+// "_SHA" doesn't match "_SHA256".
+var macs = []string{"SHA256", "SHA384", "SHA", "MD5", "SM3", "OMAC", "IMIT", "NULL"}
+
+type suite struct {
+	id          uint16
+	name        string
+	keyExchange string
+	auth        string
+	cipher      string
+	mode        string
+	keyBits     int
+	mac         string
+	aead        bool
+	minVersion  string
+	recommended bool
+	signaling   bool
+	export      bool
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatalf("usage: gensuites [-o suites_gen.go] tls-parameters-4.csv")
+	}
+	suites, err := readRegistry(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("unable to read the IANA registry: %s", err)
+	}
+	for _, e := range extraSuites {
+		if _, ok := suites[e.id]; ok {
+			log.Fatalf("extra suite %#04x (%s) is in the IANA registry now; remove it from extraSuites", e.id, e.name)
+		}
+		parseAs := e.parseAs
+		if parseAs == "" {
+			parseAs = e.name
+		}
+		s, err := parseSuite(e.id, parseAs)
+		if err != nil {
+			log.Fatalf("unable to parse extra suite: %s", err)
+		}
+		s.name = e.name
+		suites[e.id] = s
+	}
+	src, err := generate(suites)
+	if err != nil {
+		log.Fatalf("unable to generate %s: %s", *outFile, err)
+	}
+	if err := ioutil.WriteFile(*outFile, src, 0644); err != nil {
+		log.Fatalf("unable to write %s: %s", *outFile, err)
+	}
+}
+
+// readRegistry reads the IANA CSV, skipping the unassigned and reserved
+// ranges. The single reserved values from RFC 8701 are GREASE and named
+// TLS_GREASE_XX.
+func readRegistry(path string) (map[uint16]suite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(header) < 4 || header[0] != "Value" || header[1] != "Description" || header[3] != "Recommended" {
+		return nil, fmt.Errorf("unexpected header %q", header)
+	}
+	suites := make(map[uint16]suite)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, desc := rec[0], rec[1]
+		if strings.ContainsAny(value, "-*") {
+			continue
+		}
+		id, err := parseValue(value)
+		if err != nil {
+			return nil, err
+		}
+		var s suite
+		switch {
+		case desc == "Reserved" && isGREASE(id):
+			s = suite{id: id, name: fmt.Sprintf("TLS_GREASE_%02X", id>>8), signaling: true}
+		case strings.HasPrefix(desc, "TLS_"):
+			s, err = parseSuite(id, desc)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+		s.recommended = rec[3] == "Y"
+		suites[id] = s
+	}
+	return suites, nil
+}
+
+// parseValue parses a registry value like "0xC0,0x2F".
+func parseValue(v string) (uint16, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("value %#v is not of the form 0xXX,0xXX", v)
+	}
+	hi, err := strconv.ParseUint(parts[0], 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("value %#v: %s", v, err)
+	}
+	lo, err := strconv.ParseUint(parts[1], 0, 8)
+	if err != nil {
+		return 0, fmt.Errorf("value %#v: %s", v, err)
+	}
+	return uint16(hi)<<8 | uint16(lo), nil
+}
+
+func isGREASE(id uint16) bool {
+	return id&0x0f0f == 0x0a0a && id>>8 == id&0xff
+}
+
+// parseSuite fills in a suite's algorithms from its name.
+func parseSuite(id uint16, name string) (suite, error) {
+	s := suite{id: id, name: name}
+	if strings.HasSuffix(name, "_SCSV") {
+		s.signaling = true
+		return s, nil
+	}
+	rest := strings.TrimPrefix(name, "TLS_")
+	tls13 := false
+	if i := strings.Index(rest, "_WITH_"); i >= 0 {
+		kx := rest[:i]
+		rest = rest[i+len("_WITH_"):]
+		if strings.HasSuffix(kx, "_EXPORT1024") || strings.HasSuffix(kx, "_EXPORT") {
+			s.export = true
+			kx = kx[:strings.LastIndex(kx, "_EXPORT")]
+		}
+		algs, ok := keyExchanges[kx]
+		if !ok {
+			return s, fmt.Errorf("suite %#04x (%s) has unknown key exchange %#v", id, name, kx)
+		}
+		s.keyExchange, s.auth = algs[0], algs[1]
+		// The GOST suites with MGM are TLS 1.3 suites that kept the TLS
+		// 1.2 naming.
+		tls13 = strings.Contains(rest, "_MGM_")
+	} else {
+		// TLS 1.3 suites only name the AEAD and hash; the key exchange
+		// and authentication are negotiated separately.
+		tls13 = true
+	}
+	if tls13 {
+		s.keyExchange, s.auth = "", ""
+	}
+
+	bulk := rest
+	for _, mac := range macs {
+		if strings.HasSuffix(rest, "_"+mac) {
+			s.mac = mac
+			bulk = strings.TrimSuffix(rest, "_"+mac)
+			break
+		}
+	}
+	c, ok := bulkCiphers[bulk]
+	if !ok {
+		return s, fmt.Errorf("suite %#04x (%s) has unknown bulk cipher %#v", id, name, bulk)
+	}
+	s.cipher, s.mode, s.keyBits, s.aead = c.cipher, c.mode, c.keyBits, c.aead
+	if s.aead && s.mac == "" {
+		// The CCM suites from RFC 6655 and the GOST MGM suites don't
+		// name their PRF hash.
+		s.mac = "SHA256"
+		if strings.Contains(bulk, "MGM") {
+			s.mac = "STREEBOG256"
+		}
+	}
+
+	switch {
+	case tls13:
+		s.minVersion = "tls.VersionTLS13"
+	case s.aead || s.mac == "SHA256" || s.mac == "SHA384" || s.mac == "OMAC" || s.mac == "IMIT":
+		s.minVersion = "tls.VersionTLS12"
+	case id <= 0x001B:
+		s.minVersion = "tls.VersionSSL30"
+	default:
+		s.minVersion = "tls.VersionTLS10"
+	}
+	return s, nil
+}
+
+func generate(suites map[uint16]suite) ([]byte, error) {
+	ids := make([]int, 0, len(suites))
+	for id := range suites {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gensuites from gensuites/tls-parameters-4.csv. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package main\n\n")
+	fmt.Fprintf(&b, "import tls \"github.com/sullivanmatt/check.tls.support/tls110\"\n\n")
+	fmt.Fprintf(&b, "var cipherSuites = map[uint16]cipherSuite{\n")
+	for _, id := range ids {
+		s := suites[uint16(id)]
+		fields := []string{fmt.Sprintf("ID: 0x%04X", s.id), fmt.Sprintf("Name: %q", s.name)}
+		str := func(k, v string) {
+			if v != "" {
+				fields = append(fields, fmt.Sprintf("%s: %q", k, v))
+			}
+		}
+		boolean := func(k string, v bool) {
+			if v {
+				fields = append(fields, k+": true")
+			}
+		}
+		str("KeyExchange", s.keyExchange)
+		str("Auth", s.auth)
+		str("Cipher", s.cipher)
+		str("Mode", s.mode)
+		if s.keyBits != 0 {
+			fields = append(fields, fmt.Sprintf("KeyBits: %d", s.keyBits))
+		}
+		str("MAC", s.mac)
+		boolean("AEAD", s.aead)
+		if s.minVersion != "" {
+			fields = append(fields, "MinVersion: "+s.minVersion)
+		}
+		boolean("Export", s.export)
+		boolean("Signaling", s.signaling)
+		boolean("Recommended", s.recommended)
+		fmt.Fprintf(&b, "\t0x%04X: {%s},\n", s.id, strings.Join(fields, ", "))
+	}
+	fmt.Fprintf(&b, "}\n")
+	return format.Source(b.Bytes())
+}
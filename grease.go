@@ -0,0 +1,54 @@
+package main
+
+import (
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// greaseInfo reports, per ClientHello field, whether the client sent any of
+// the reserved GREASE values of RFC 8701. Clients that do are exercising the
+// server's tolerance of unknown values, which keeps the ecosystem able to
+// deploy new ones.
+type greaseInfo struct {
+	CipherSuites        bool `json:"cipher_suites"`
+	Extensions          bool `json:"extensions"`
+	SupportedGroups     bool `json:"supported_groups"`
+	SignatureAlgorithms bool `json:"signature_algorithms"`
+	SupportedVersions   bool `json:"supported_versions"`
+	ALPN                bool `json:"alpn"`
+}
+
+// isGREASE reports whether v is one of the reserved GREASE values of RFC
+// 8701 (0x0a0a, 0x1a1a, ..., 0xfafa), which clients sprinkle into cipher
+// suites, extensions, groups and other fields.
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+// isGREASEALPN reports whether proto is one of the two-byte GREASE ALPN
+// identifiers of RFC 8701, section 3.
+func isGREASEALPN(proto string) bool {
+	return len(proto) == 2 && isGREASE(uint16(proto[0])<<8|uint16(proto[1]))
+}
+
+func pullGREASEInfo(st *tls.ConnectionState) greaseInfo {
+	var g greaseInfo
+	for _, cs := range st.ClientCipherSuites {
+		g.CipherSuites = g.CipherSuites || isGREASE(cs)
+	}
+	for _, ext := range st.ClientHelloExtensions {
+		g.Extensions = g.Extensions || isGREASE(ext.Type)
+	}
+	for _, c := range st.ClientCurves {
+		g.SupportedGroups = g.SupportedGroups || isGREASE(uint16(c))
+	}
+	for _, sa := range st.ClientSignatureAlgorithms {
+		g.SignatureAlgorithms = g.SignatureAlgorithms || isGREASE(uint16(sa))
+	}
+	for _, v := range st.SupportedVersions {
+		g.SupportedVersions = g.SupportedVersions || isGREASE(v)
+	}
+	for _, p := range st.ClientALPNProtocols {
+		g.ALPN = g.ALPN || isGREASEALPN(p)
+	}
+	return g
+}
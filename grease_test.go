@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func TestIsGREASE(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		v := uint16(i<<12 | 0x0a00 | i<<4 | 0x0a)
+		if !isGREASE(v) {
+			t.Errorf("isGREASE(%#04x) = false", v)
+		}
+	}
+	for _, v := range []uint16{0x0a1a, 0x1301, 0x0000, 0x0a0b, 0xfafb} {
+		if isGREASE(v) {
+			t.Errorf("isGREASE(%#04x) = true", v)
+		}
+	}
+}
+
+func TestPullGREASEInfo(t *testing.T) {
+	st := testClientHelloState([]uint16{0x2a2a, 0, 23, 43})
+	st.SupportedVersions = []uint16{0x3a3a, tls.VersionTLS13}
+	got := pullGREASEInfo(st)
+	want := greaseInfo{
+		CipherSuites:      true,
+		Extensions:        true,
+		SupportedGroups:   true,
+		SupportedVersions: true,
+	}
+	if got != want {
+		t.Errorf("pullGREASEInfo: want %+v, got %+v", want, got)
+	}
+
+	st.ClientALPNProtocols = append([]string{"\x5a\x5a"}, st.ClientALPNProtocols...)
+	st.ClientSignatureAlgorithms = append(st.ClientSignatureAlgorithms, 0x6a6a)
+	got = pullGREASEInfo(st)
+	if !got.ALPN || !got.SignatureAlgorithms {
+		t.Errorf("pullGREASEInfo with GREASE ALPN and signature algorithm: got %+v", got)
+	}
+
+	if got := pullGREASEInfo(&tls.ConnectionState{}); got != (greaseInfo{}) {
+		t.Errorf("pullGREASEInfo of an empty ClientHello: got %+v", got)
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// allGroups maps the supported_groups (formerly elliptic_curves) code points
+// to their names in the IANA TLS Supported Groups registry.
+// https://www.iana.org/assignments/tls-parameters/tls-parameters.xhtml#tls-parameters-8
+var allGroups = map[tls.CurveID]string{
+	1:      "sect163k1",
+	2:      "sect163r1",
+	3:      "sect163r2",
+	4:      "sect193r1",
+	5:      "sect193r2",
+	6:      "sect233k1",
+	7:      "sect233r1",
+	8:      "sect239k1",
+	9:      "sect283k1",
+	10:     "sect283r1",
+	11:     "sect409k1",
+	12:     "sect409r1",
+	13:     "sect571k1",
+	14:     "sect571r1",
+	15:     "secp160k1",
+	16:     "secp160r1",
+	17:     "secp160r2",
+	18:     "secp192k1",
+	19:     "secp192r1",
+	20:     "secp224k1",
+	21:     "secp224r1",
+	22:     "secp256k1",
+	23:     "secp256r1",
+	24:     "secp384r1",
+	25:     "secp521r1",
+	26:     "brainpoolP256r1",
+	27:     "brainpoolP384r1",
+	28:     "brainpoolP512r1",
+	29:     "x25519",
+	30:     "x448",
+	31:     "brainpoolP256r1tls13",
+	32:     "brainpoolP384r1tls13",
+	33:     "brainpoolP512r1tls13",
+	34:     "GC256A",
+	35:     "GC256B",
+	36:     "GC256C",
+	37:     "GC256D",
+	38:     "GC512A",
+	39:     "GC512B",
+	40:     "GC512C",
+	41:     "curveSM2",
+	256:    "ffdhe2048",
+	257:    "ffdhe3072",
+	258:    "ffdhe4096",
+	259:    "ffdhe6144",
+	260:    "ffdhe8192",
+	512:    "MLKEM512",
+	513:    "MLKEM768",
+	514:    "MLKEM1024",
+	0x11eb: "SecP256r1MLKEM768",
+	0x11ec: "X25519MLKEM768",
+	0x11ed: "SecP384r1MLKEM1024",
+	0xff01: "arbitrary_explicit_prime_curves",
+	0xff02: "arbitrary_explicit_char2_curves",
+
+	// Pre-standard hybrids from draft-tls-westerbaan-xyber768d00 that
+	// browsers shipped before ML-KEM was final. Not in the IANA registry.
+	0x6399: "X25519Kyber768Draft00",
+	0x639a: "SecP256r1Kyber768Draft00",
+}
+
+// postQuantumGroups are the groups whose key exchange resists a quantum
+// adversary, either as pure ML-KEM or as a hybrid with a classical group.
+var postQuantumGroups = map[tls.CurveID]bool{
+	512:    true,
+	513:    true,
+	514:    true,
+	0x11eb: true,
+	0x11ec: true,
+	0x11ed: true,
+	0x6399: true,
+	0x639a: true,
+}
+
+var (
+	lowSecurityGroupReason = "The group provides less than 128 bits of security."
+	deprecatedGroupReason  = "The group was deprecated for TLS by RFC 8422 and RFC 8446 and is rarely implemented or reviewed."
+)
+
+// groupSecurityBits is the approximate classical security strength in bits
+// of each group, following NIST SP 800-57 Part 1 for the prime field and
+// finite field groups. Hybrid post-quantum groups are rated by their ML-KEM
+// half, whose parameter sets target AES-128, AES-192 and AES-256.
+var groupSecurityBits = map[tls.CurveID]int{
+	1:      80,
+	2:      80,
+	3:      80,
+	4:      96,
+	5:      96,
+	6:      112,
+	7:      112,
+	8:      112,
+	9:      128,
+	10:     128,
+	11:     192,
+	12:     192,
+	13:     256,
+	14:     256,
+	15:     80,
+	16:     80,
+	17:     80,
+	18:     96,
+	19:     96,
+	20:     112,
+	21:     112,
+	22:     128,
+	23:     128,
+	24:     192,
+	25:     256,
+	26:     128,
+	27:     192,
+	28:     256,
+	29:     128,
+	30:     224,
+	31:     128,
+	32:     192,
+	33:     256,
+	34:     128,
+	35:     128,
+	36:     128,
+	37:     128,
+	38:     256,
+	39:     256,
+	40:     256,
+	41:     128,
+	256:    112,
+	257:    128,
+	258:    152,
+	259:    176,
+	260:    200,
+	512:    128,
+	513:    192,
+	514:    256,
+	0x11eb: 192,
+	0x11ec: 192,
+	0x11ed: 256,
+	0x6399: 192,
+	0x639a: 192,
+}
+
+// deprecatedGroups are the elliptic curves RFC 8422 deprecated and TLS 1.3
+// never adopted, along with the explicit curve encodings.
+var deprecatedGroups = map[tls.CurveID]bool{
+	1: true, 2: true, 3: true, 4: true, 5: true, 6: true, 7: true,
+	8: true, 9: true, 10: true, 11: true, 12: true, 13: true, 14: true,
+	15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true,
+	22: true, 26: true, 27: true, 28: true,
+	0xff01: true,
+	0xff02: true,
+}
+
+// allPointFormats maps the ec_point_formats code points to their names in
+// the IANA EC Point Format registry.
+var allPointFormats = map[uint8]string{
+	0: "uncompressed",
+	1: "ansiX962_compressed_prime",
+	2: "ansiX962_compressed_char2",
+}
+
+// namedGroup is one group the client offered in supported_groups.
+type namedGroup struct {
+	ID            uint16 `json:"id"`
+	Name          string `json:"name"`
+	SecurityBits  int    `json:"security_bits"`  // 0 if unknown
+	SecurityLevel string `json:"security_level"` // "low", "medium", "high" or "unknown"
+}
+
+// securityLevel buckets a security strength in bits.
+func securityLevel(bits int) string {
+	switch {
+	case bits == 0:
+		return "unknown"
+	case bits < 128:
+		return "low"
+	case bits < 192:
+		return "medium"
+	}
+	return "high"
+}
+
+// namedGroups describes each non-GREASE group in ids, keeping their order.
+func namedGroups(ids []tls.CurveID) []namedGroup {
+	groups := []namedGroup{}
+	for _, id := range ids {
+		if isGREASE(uint16(id)) {
+			continue
+		}
+		bits := groupSecurityBits[id]
+		groups = append(groups, namedGroup{
+			ID:            uint16(id),
+			Name:          groupName(id),
+			SecurityBits:  bits,
+			SecurityLevel: securityLevel(bits),
+		})
+	}
+	return groups
+}
+
+// weakGroupReasons returns why the group is weak, or nothing if it isn't.
+func weakGroupReasons(id tls.CurveID) []string {
+	var reasons []string
+	if bits, ok := groupSecurityBits[id]; ok && bits < 128 {
+		reasons = append(reasons, lowSecurityGroupReason)
+	}
+	if deprecatedGroups[id] {
+		reasons = append(reasons, deprecatedGroupReason)
+	}
+	return reasons
+}
+
+// pointFormatNames names each point format in formats, keeping their order.
+func pointFormatNames(formats []uint8) []string {
+	names := []string{}
+	for _, f := range formats {
+		if s, ok := allPointFormats[f]; ok {
+			names = append(names, s)
+		} else {
+			names = append(names, fmt.Sprintf("An unknown point format: %#02x", f))
+		}
+	}
+	return names
+}
+
+// groupName returns the registry name of the group id.
+func groupName(id tls.CurveID) string {
+	if s, ok := allGroups[id]; ok {
+		return s
+	}
+	return fmt.Sprintf("An unknown group: %#04x", uint16(id))
+}
+
+// parseGroupNames parses a comma-separated list of registry group names,
+// all of which the server must be able to do ECDHE with.
+func parseGroupNames(s string) ([]tls.CurveID, error) {
+	var ids []tls.CurveID
+NextName:
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		for id, n := range allGroups {
+			if strings.EqualFold(n, name) {
+				if !tls.SupportsCurve(id) {
+					return nil, fmt.Errorf("group %q is not supported for ECDHE; use x25519, secp256r1, secp384r1 or secp521r1", name)
+				}
+				ids = append(ids, id)
+				continue NextName
+			}
+		}
+		return nil, fmt.Errorf("unknown group %q", name)
+	}
+	return ids, nil
+}
+
+// groupNames names each non-GREASE group in ids, keeping their order.
+func groupNames(ids []tls.CurveID) []string {
+	names := []string{}
+	for _, id := range ids {
+		if !isGREASE(uint16(id)) {
+			names = append(names, groupName(id))
+		}
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func TestNamedGroups(t *testing.T) {
+	got := namedGroups([]tls.CurveID{0x2a2a, tls.X25519, 16, 256, 0x11ec, 0xfefe})
+	want := []namedGroup{
+		{ID: 29, Name: "x25519", SecurityBits: 128, SecurityLevel: "medium"},
+		{ID: 16, Name: "secp160r1", SecurityBits: 80, SecurityLevel: "low"},
+		{ID: 256, Name: "ffdhe2048", SecurityBits: 112, SecurityLevel: "low"},
+		{ID: 0x11ec, Name: "X25519MLKEM768", SecurityBits: 192, SecurityLevel: "high"},
+		{ID: 0xfefe, Name: "An unknown group: 0xfefe", SecurityBits: 0, SecurityLevel: "unknown"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("namedGroups:\nwant %+v\ngot  %+v", want, got)
+	}
+}
+
+func TestWeakGroupReasons(t *testing.T) {
+	tests := []struct {
+		id   tls.CurveID
+		want []string
+	}{
+		{1, []string{lowSecurityGroupReason, deprecatedGroupReason}},
+		{16, []string{lowSecurityGroupReason, deprecatedGroupReason}},
+		{26, []string{deprecatedGroupReason}},
+		{256, []string{lowSecurityGroupReason}},
+		{0xff01, []string{deprecatedGroupReason}},
+		{tls.X25519, nil},
+		{tls.CurveP256, nil},
+		{257, nil},
+		{0xfefe, nil},
+	}
+	for _, tt := range tests {
+		if got := weakGroupReasons(tt.id); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("weakGroupReasons(%s): want %v, got %v", groupName(tt.id), tt.want, got)
+		}
+	}
+}
+
+func TestPointFormatNames(t *testing.T) {
+	got := pointFormatNames([]uint8{0, 1, 2, 7})
+	want := []string{"uncompressed", "ansiX962_compressed_prime", "ansiX962_compressed_char2", "An unknown point format: 0x07"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pointFormatNames: want %v, got %v", want, got)
+	}
+}
+
+func TestParseGroupNames(t *testing.T) {
+	got, err := parseGroupNames("x25519, secp256r1,SECP384R1")
+	if err != nil {
+		t.Fatalf("parseGroupNames: %s", err)
+	}
+	want := []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGroupNames: want %v, got %v", want, got)
+	}
+	if _, err := parseGroupNames("x25519,p256"); err == nil {
+		t.Errorf("parseGroupNames accepted an unknown group name")
+	}
+	for _, name := range []string{"secp160r1", "ffdhe2048", "x25519,X25519MLKEM768"} {
+		_, err := parseGroupNames(name)
+		if err == nil || !strings.Contains(err.Error(), "not supported for ECDHE") {
+			t.Errorf("parseGroupNames(%q): want an unsupported group error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultHMACKeyID is the kid of a secret given on its own rather than in a
+// secrets file.
+const defaultHMACKeyID = "default"
+
+// hmacKeySet is the secrets API responses can be HMAC'd with. Only the
+// signing key is used, but keeping the previous ones listed lets the file
+// double as the list of secrets verifiers should accept while rotating.
+type hmacKeySet struct {
+	signingKID string
+	secrets    map[string][]byte
+}
+
+// hmacKeysFile is the JSON format of the HMAC secrets file and environment
+// variable:
+//
+//	{"signing_key": "2026-10", "keys": [{"kid": "2026-10", "secret": "..."}]}
+type hmacKeysFile struct {
+	SigningKey string        `json:"signing_key"`
+	Keys       []hmacKeyInfo `json:"keys"`
+}
+
+type hmacKeyInfo struct {
+	KID    string `json:"kid"`
+	Secret string `json:"secret"`
+}
+
+func parseHMACKeys(b []byte) (*hmacKeySet, error) {
+	kf := &hmacKeysFile{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(kf)
+	if err != nil {
+		return nil, err
+	}
+	ks := &hmacKeySet{
+		signingKID: kf.SigningKey,
+		secrets:    make(map[string][]byte),
+	}
+	for i, k := range kf.Keys {
+		if k.KID == "" || k.Secret == "" {
+			return nil, fmt.Errorf("key %d is missing a kid or secret", i)
+		}
+		if _, ok := ks.secrets[k.KID]; ok {
+			return nil, fmt.Errorf("kid %#v is used by more than one key", k.KID)
+		}
+		ks.secrets[k.KID] = []byte(k.Secret)
+	}
+	if _, ok := ks.secrets[ks.signingKID]; !ok {
+		return nil, fmt.Errorf("signing key %#v is not one of the keys", ks.signingKID)
+	}
+	return ks, nil
+}
+
+func loadHMACKeysFile(fp string) (*hmacKeySet, error) {
+	b, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open HMAC secrets file %#v: %s", fp, err)
+	}
+	ks, err := parseHMACKeys(b)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse HMAC secrets file %#v: %s", fp, err)
+	}
+	return ks, nil
+}
+
+// loadHMACKeysEnv loads the secrets from the environment variable name. So
+// that an existing secret can be moved there as is, a value that isn't a
+// JSON object is taken to be a single secret.
+func loadHMACKeysEnv(name string) (*hmacKeySet, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil, fmt.Errorf("HMAC secrets environment variable %s is empty", name)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(v), "{") {
+		return singleHMACKey(v), nil
+	}
+	ks, err := parseHMACKeys([]byte(v))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse HMAC secrets environment variable %s: %s", name, err)
+	}
+	return ks, nil
+}
+
+func singleHMACKey(secret string) *hmacKeySet {
+	return &hmacKeySet{
+		signingKID: defaultHMACKeyID,
+		secrets:    map[string][]byte{defaultHMACKeyID: []byte(secret)},
+	}
+}
+
+// sign returns the base64-encoded HMAC-SHA256 of b and the kid of the
+// secret used.
+func (ks *hmacKeySet) sign(b []byte) (string, string) {
+	mac := hmac.New(sha256.New, ks.secrets[ks.signingKID])
+	mac.Write(b)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), ks.signingKID
+}
+
+// newHMACKeyReloader returns a reloader of the secrets in the file at path.
+// If path is empty, the keys never change.
+func newHMACKeyReloader(path string, keys *hmacKeySet) *hmacKeyReloader {
+	return &hmacKeyReloader{
+		path: path,
+		keys: keys,
+	}
+}
+
+// reloadHMACKeysForever reloads the secrets file on every tick and every
+// signal sent to hup, like SIGHUP.
+func reloadHMACKeysForever(hkr *hmacKeyReloader, tick *time.Ticker, hup <-chan os.Signal) {
+	for {
+		select {
+		case <-tick.C:
+		case <-hup:
+			log.Printf("reloading HMAC secrets from %#v", hkr.path)
+		}
+		if err := hkr.maybeReload(); err != nil {
+			log.Printf("error when attempting reload of HMAC secrets: %s", err)
+		}
+	}
+}
+
+type hmacKeyReloader struct {
+	keysMu sync.RWMutex
+	keys   *hmacKeySet
+	path   string
+}
+
+func (hkr *hmacKeyReloader) maybeReload() error {
+	if hkr.path == "" {
+		return errors.New("no HMAC secrets file to reload")
+	}
+	ks, err := loadHMACKeysFile(hkr.path)
+	if err != nil {
+		return err
+	}
+	hkr.keysMu.Lock()
+	defer hkr.keysMu.Unlock()
+	hkr.keys = ks
+	return nil
+}
+
+func (hkr *hmacKeyReloader) Keys() *hmacKeySet {
+	hkr.keysMu.RLock()
+	defer hkr.keysMu.RUnlock()
+	return hkr.keys
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sullivanmatt/check.tls.support/verify"
+)
+
+func TestParseHMACKeys(t *testing.T) {
+	ks, err := parseHMACKeys([]byte(`{"signing_key": "new", "keys": [{"kid": "old", "secret": "s1"}, {"kid": "new", "secret": "s2"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"claims": {"iat": 1, "exp": 2}}`)
+	sig, kid := ks.sign(body)
+	if kid != "new" {
+		t.Errorf("want the signing key's kid, got %#v", kid)
+	}
+	_, err = verify.HMACWithKID(body, sig, kid, ks.secrets, verify.Options{Now: time.Unix(1, 0)})
+	if err != nil {
+		t.Errorf("signature doesn't verify with the signing key's secret: %s", err)
+	}
+
+	tests := []struct {
+		json   string
+		errStr string
+	}{
+		{`{"signing_key": "a", "keys": [{"kid": "a", "secret": ""}]}`, "missing a kid or secret"},
+		{`{"signing_key": "a", "keys": [{"kid": "a", "secret": "1"}, {"kid": "a", "secret": "2"}]}`, "more than one key"},
+		{`{"signing_key": "b", "keys": [{"kid": "a", "secret": "1"}]}`, "not one of the keys"},
+		{`{"signing_key": "a", "secrets": {}}`, "unknown field"},
+	}
+	for i, tt := range tests {
+		_, err := parseHMACKeys([]byte(tt.json))
+		if err == nil || !strings.Contains(err.Error(), tt.errStr) {
+			t.Errorf("#%d: want error containing %q, got %v", i, tt.errStr, err)
+		}
+	}
+}
+
+func TestLoadHMACKeysEnv(t *testing.T) {
+	const name = "TEST_HOWSMYSSL_HMAC_SECRETS"
+	defer os.Unsetenv(name)
+
+	os.Setenv(name, "plain secret")
+	ks, err := loadHMACKeysEnv(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ks.signingKID != defaultHMACKeyID || string(ks.secrets[defaultHMACKeyID]) != "plain secret" {
+		t.Errorf("single secret: want it under kid %#v, got %+v", defaultHMACKeyID, ks)
+	}
+
+	os.Setenv(name, `{"signing_key": "a", "keys": [{"kid": "a", "secret": "s"}]}`)
+	ks, err = loadHMACKeysEnv(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ks.signingKID != "a" {
+		t.Errorf("JSON secrets: want kid a, got %+v", ks)
+	}
+
+	os.Setenv(name, "")
+	_, err = loadHMACKeysEnv(name)
+	if err == nil {
+		t.Errorf("empty variable: want an error")
+	}
+}
+
+func TestReloadHMACKeys(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "hmac.json")
+	write := func(kid string) {
+		err := ioutil.WriteFile(fp, []byte(`{"signing_key": "`+kid+`", "keys": [{"kid": "`+kid+`", "secret": "s"}]}`), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("first")
+	ks, err := loadHMACKeysFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hkr := newHMACKeyReloader(fp, ks)
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	hup := make(chan os.Signal, 1)
+	go reloadHMACKeysForever(hkr, tick, hup)
+
+	write("second")
+	hup <- syscall.SIGHUP
+	deadline := time.Now().Add(5 * time.Second)
+	for hkr.Keys().signingKID != "second" {
+		if time.Now().After(deadline) {
+			t.Fatalf("keys weren't reloaded on SIGHUP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A bad file leaves the current keys in place.
+	err = ioutil.WriteFile(fp, []byte("{"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hkr.maybeReload(); err == nil {
+		t.Errorf("want an error reloading a bad file")
+	}
+	if hkr.Keys().signingKID != "second" {
+		t.Errorf("bad file replaced the keys")
+	}
+}
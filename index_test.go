@@ -8,14 +8,15 @@ import (
 	"errors"
 	"expvar"
 	"io/ioutil"
-	"net"
 	"net/http"
 	"net/http/httptest"
-	"strings"
+	"net/http/httptrace"
 	"testing"
 	"time"
 
 	tls110 "github.com/sullivanmatt/check.tls.support/tls110"
+	"github.com/sullivanmatt/check.tls.support/verify"
+	"golang.org/x/net/http2"
 )
 
 func TestDumbNilishIndex(t *testing.T) {
@@ -30,164 +31,6 @@ func TestDumbNilishIndex(t *testing.T) {
 	}
 }
 
-type acmeTest struct {
-	challPath       string
-	acmeRedirectURL string
-	expected        string
-	code            int
-}
-
-func TestACMERedirect(t *testing.T) {
-	staticVars := new(expvar.Map).Init()
-	staticHandler := makeStaticHandler("/static", staticVars)
-	webHandleFunc := http.NotFound
-	tests := []acmeTest{
-		// same domain redirect, acmeRedirectURL leads with "/"
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "/example.com",
-			expected:        "/example.com/.well-known/acme-challenge/foobar",
-		},
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "http://example.com",
-			expected:        "http://example.com/.well-known/acme-challenge/foobar",
-		},
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "http://example.com/",
-			expected:        "http://example.com/.well-known/acme-challenge/foobar",
-		},
-		// Busted acmeRedirectURL. Meant to be a domain, but was not.
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "example.com",
-			expected:        "/.well-known/acme-challenge/example.com/.well-known/acme-challenge/foobar",
-		},
-		// same domain redirect, acmeRedirectURL leads and trails with "/"
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "/okay/",
-			expected:        "/okay/.well-known/acme-challenge/foobar",
-		},
-		// same domain redirect, acmeRedirectURL leads and trails with "/"
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "/okay/",
-			expected:        "/okay/.well-known/acme-challenge/foobar",
-		},
-		// same domain redirect, acmeRedirectURL leads with "/"
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "/okay",
-			expected:        "/okay/.well-known/acme-challenge/foobar",
-		},
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge",
-			acmeRedirectURL: "http://example.com",
-			expected:        "/.well-known/acme-challenge/",
-			code:            http.StatusMovedPermanently,
-		},
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/",
-			acmeRedirectURL: "http://example.com",
-			expected:        "",
-			code:            http.StatusOK,
-		},
-		{
-			challPath:       "https://www.howsmyssl.com/.well-known/acme-challenge/foobar",
-			acmeRedirectURL: "http://example.com",
-			expected:        "http://example.com/.well-known/acme-challenge/foobar",
-		},
-	}
-	for i, tt := range tests {
-		tm := tlsMux("www.howsmyssl.com", "www.howsmyssl.com", tt.acmeRedirectURL, staticHandler, webHandleFunc, nil)
-		r, err := http.NewRequest("GET", tt.challPath, nil)
-		if err != nil {
-			t.Fatalf("borked request for %#v: %s", tt.challPath, err)
-		}
-		w := httptest.NewRecorder()
-		tm.ServeHTTP(w, r)
-
-		location := w.Header().Get("Location")
-		if tt.code == 0 {
-			tt.code = http.StatusFound
-		}
-		if w.Code != tt.code {
-			t.Errorf("#%d, want %d, got %d", i, tt.code, w.Code)
-		}
-		if location != tt.expected {
-			t.Errorf("#%d, %q, want %#v, got %#v", i, tt.acmeRedirectURL, tt.expected, location)
-		}
-	}
-}
-
-type vhostTest struct {
-	rawVHost  string
-	httpsAddr string
-
-	expectedRouteHost    string
-	expectedRedirectHost string
-}
-
-func TestVHostCalculation(t *testing.T) {
-	tests := []vhostTest{
-		vhostTest{
-			rawVHost:             "www.howsmyssl.com",
-			httpsAddr:            "0:443",
-			expectedRouteHost:    "www.howsmyssl.com",
-			expectedRedirectHost: "www.howsmyssl.com",
-		},
-		vhostTest{
-			rawVHost:             "localhost:443",
-			httpsAddr:            "localhost:443",
-			expectedRouteHost:    "localhost",
-			expectedRedirectHost: "localhost:443",
-		},
-		vhostTest{
-			rawVHost:             "example.com:443",
-			httpsAddr:            "localhost:443",
-			expectedRouteHost:    "example.com",
-			expectedRedirectHost: "example.com:443",
-		},
-		vhostTest{
-			rawVHost:             "example.com:443",
-			httpsAddr:            "0:443",
-			expectedRouteHost:    "example.com",
-			expectedRedirectHost: "example.com",
-		},
-	}
-	staticVars := new(expvar.Map).Init()
-	staticHandler := makeStaticHandler("/static", staticVars)
-	webHandleFunc := http.NotFound
-
-	for i, vt := range tests {
-		routeHost, redirectHost := calculateDomains(vt.rawVHost, vt.httpsAddr)
-		if routeHost != vt.expectedRouteHost {
-			t.Errorf("#%d vhost %#v, httpsAddr %#v: want routeHost %#v, got %s", i, vt.rawVHost, vt.httpsAddr, vt.expectedRouteHost, routeHost)
-		}
-		if redirectHost != vt.expectedRedirectHost {
-			t.Errorf("#%d vhost %#v, httpsAddr %#v: want redirectHost %#v, got %#v", i, vt.rawVHost, vt.httpsAddr, vt.expectedRedirectHost, redirectHost)
-		}
-
-		tm := tlsMux(vt.expectedRouteHost, vt.expectedRedirectHost, "http://otherexample.com", staticHandler, webHandleFunc, nil)
-		r, err := http.NewRequest("GET", "https://howsmyssl.com/", nil)
-		if err != nil {
-			t.Fatalf("borked request")
-		}
-		w := httptest.NewRecorder()
-		tm.ServeHTTP(w, r)
-		expectedLocation := "https://" + vt.expectedRedirectHost + "/"
-		location := w.Header()["Location"][0]
-		if w.Code != http.StatusMovedPermanently {
-			t.Errorf("#%d vhost %#v, httpsAddr %#v: want Code %d, got %d", i, vt.rawVHost, vt.httpsAddr, http.StatusMovedPermanently, w.Code)
-		}
-		if location != expectedLocation {
-			t.Errorf("#%d vhost %#v, httpsAddr %#v: want Location %s, got %s", i, vt.rawVHost, vt.httpsAddr, expectedLocation, location)
-		}
-	}
-}
-
 func TestDisallowedBodyParses(t *testing.T) {
 	e := &struct {
 		Error      string `json:"error"`
@@ -211,109 +54,200 @@ func TestJSONAPI(t *testing.T) {
 	ama := &allowMapsAtomic{}
 	ama.Store(am)
 	oa := newOriginAllower(ama, "testhostname", nullLogClient{}, new(expvar.Map).Init())
-	tm := tlsMux("", "www.howsmyssl.com", "www.howsmyssl.com", staticHandler, webHandleFunc, oa)
+	rpa := &ratingPolicyAtomic{}
+	rpa.Store(defaultRatingPolicy)
+	fdba := &fingerprintDBAtomic{}
+	fdba.Store(emptyFingerprintDB)
+	jks, err := loadJWSKeySet("./config/development_signing_keys.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jksa := &jwsKeySetAtomic{}
+	jksa.Store(jks)
+	hkr := newHMACKeyReloader("", singleHMACKey("test secret"))
+	tm := tlsMux("", staticHandler, webHandleFunc, oa, rpa, fdba, jksa, hkr)
 
 	tl, err := tls110.Listen("tcp", "127.0.0.1:0", serverConf)
 	if err != nil {
 		t.Fatalf("NewListener: %s", err)
 	}
 	li := newListener(tl, new(expvar.Map).Init())
-
-	srv := httptest.NewUnstartedServer(tm)
-	srv.Listener = li
-	// Intentionally not using StartTLS to avoid stomping on our special listener.
-	srv.Start()
+	srv := &http.Server{Handler: tm, ConnContext: connContext}
+	h2s := &http2.Server{}
+	err = http2.ConfigureServer(srv, h2s)
+	if err != nil {
+		t.Fatalf("ConfigureServer: %s", err)
+	}
+	go serveTLS(srv, h2s, li)
 	defer srv.Close()
+	u := "https://" + li.Addr().String()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
-	c := &http.Client{
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-				DualStack: true,
-			}).DialContext,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
+	newClient := func(nextProtos []string) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				ForceAttemptHTTP2: nextProtos[0] == "h2",
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true,
+					NextProtos:         nextProtos,
+				},
 			},
-		},
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return errors.New("no redirects should be seen")
-		},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return errors.New("no redirects should be seen")
+			},
+		}
 	}
 
 	type apiTest struct {
+		name        string
+		nextProtos  []string
 		path        string
-		origin      string
+		nonce       string
+		audience    string
 		status      int
 		contentType string
-		body        string
+		protoMajor  int
 	}
 	tests := []apiTest{
 		{
-			path:        "/a/check",
+			name:        "HTTP/2",
+			nextProtos:  []string{"h2", "http/1.1"},
+			path:        "/?nonce=c2Vzc2lvbg==&audience=example.com",
+			nonce:       "c2Vzc2lvbg==",
+			audience:    "example.com",
 			status:      http.StatusOK,
 			contentType: "application/json",
-			body:        expectedJSONBody,
+			protoMajor:  2,
 		},
 		{
-			path:        "/a/check?callback=parseTLS",
+			name:        "HTTP/1.1",
+			nextProtos:  []string{"http/1.1"},
+			path:        "/",
 			status:      http.StatusOK,
-			contentType: "application/javascript",
-			body:        "parseTLS(" + expectedJSONBody + ");",
+			contentType: "application/json",
+			protoMajor:  1,
 		},
 		{
-			path:        "/a/check",
-			origin:      "https://blocked.com",
+			name:        "unknown profile",
+			nextProtos:  []string{"h2", "http/1.1"},
+			path:        "/?profile=hipaa",
 			status:      http.StatusBadRequest,
-			contentType: "application/json",
-			body:        string(disallowedOriginBody),
+			contentType: "text/plain; charset=utf-8",
+			protoMajor:  2,
 		},
 		{
-			path:        "/a/check?callback=foobarParse",
-			origin:      "https://blocked.com",
-			status:      http.StatusOK,
-			contentType: "application/javascript",
-			body:        "foobarParse(" + string(disallowedOriginBody) + ");",
+			name:        "bad nonce",
+			nextProtos:  []string{"h2", "http/1.1"},
+			path:        "/?nonce=%3Cscript%3E",
+			status:      http.StatusBadRequest,
+			contentType: "text/plain; charset=utf-8",
+			protoMajor:  2,
 		},
 	}
-	u := strings.Replace(srv.URL, "http://", "https://", -1)
 	for _, at := range tests {
-		t.Run(at.path, func(t *testing.T) {
-			r, err := http.NewRequest("GET", u+at.path, nil)
-			if err != nil {
-				t.Fatalf("NewRequest: %s", err)
-			}
-			r = r.WithContext(ctx)
-			r.Header.Set("Origin", at.origin)
-			resp, err := c.Do(r)
-			if err != nil {
-				t.Fatalf("Get: %s", err)
-			}
-			b, err := ioutil.ReadAll(resp.Body)
-			defer resp.Body.Close()
-			if err != nil {
-				t.Fatalf("ReadAll: %s", err)
-			}
-			if resp.StatusCode != at.status {
-				t.Errorf("status code, want: %d, got: %d", at.status, resp.StatusCode)
-			}
-			if string(b) != at.body {
-				t.Errorf("body, want:\n%#v\ngot:%#v\n", at.body, string(b))
-			}
-			ct := resp.Header.Get("Content-Type")
-			if ct != at.contentType {
-				t.Errorf("Content-Type, want %s, got %s", at.contentType, ct)
+		t.Run(at.name, func(t *testing.T) {
+			c := newClient(at.nextProtos)
+			defer c.CloseIdleConnections()
+			// The second request checks that the connection was kept
+			// alive and that its handshake is still reported.
+			for i := 0; i < 2; i++ {
+				reused := false
+				trace := &httptrace.ClientTrace{
+					GotConn: func(info httptrace.GotConnInfo) { reused = info.Reused },
+				}
+				r, err := http.NewRequest("GET", u+at.path, nil)
+				if err != nil {
+					t.Fatalf("NewRequest: %s", err)
+				}
+				r = r.WithContext(httptrace.WithClientTrace(ctx, trace))
+				resp, err := c.Do(r)
+				if err != nil {
+					t.Fatalf("Get: %s", err)
+				}
+				b, err := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatalf("ReadAll: %s", err)
+				}
+				if resp.StatusCode != at.status {
+					t.Errorf("status code, want: %d, got: %d", at.status, resp.StatusCode)
+				}
+				if resp.ProtoMajor != at.protoMajor {
+					t.Errorf("ProtoMajor, want: %d, got: %d", at.protoMajor, resp.ProtoMajor)
+				}
+				if i == 1 && !reused {
+					t.Errorf("second request did not reuse the connection")
+				}
+				ct := resp.Header.Get("Content-Type")
+				if ct != at.contentType {
+					t.Errorf("Content-Type, want %s, got %s", at.contentType, ct)
+				}
+				if at.status != http.StatusOK {
+					continue
+				}
+				if resp.Header.Get("X-Response-Signature") == "" {
+					t.Errorf("no X-Response-Signature header")
+				}
+				opts := verify.Options{Nonce: at.nonce, Audience: at.audience, ClientIP: "127.0.0.1"}
+				_, err = verify.JWS(b, resp.Header.Get("X-Response-JWS"), jks.jwks, opts)
+				if err != nil {
+					t.Errorf("X-Response-JWS: %s", err)
+				}
+				kid := resp.Header.Get("X-Response-Signature-Kid")
+				if kid != defaultHMACKeyID {
+					t.Errorf("X-Response-Signature-Kid, want %#v, got %#v", defaultHMACKeyID, kid)
+				}
+				_, err = verify.HMACWithKID(b, resp.Header.Get("X-Response-Signature"), kid, hkr.Keys().secrets, opts)
+				if err != nil {
+					t.Errorf("X-Response-Signature: %s", err)
+				}
+				ci := &clientInfo{}
+				err = json.Unmarshal(b, ci)
+				if err != nil {
+					t.Fatalf("unable to parse body %#v: %s", string(b), err)
+				}
+				if ci.TLSVersion != "TLS 1.3" {
+					t.Errorf("tls_version, want %#v, got %#v", "TLS 1.3", ci.TLSVersion)
+				}
+				wantALPN := at.nextProtos[0]
+				if ci.Negotiated.ALPN != wantALPN {
+					t.Errorf("negotiated ALPN, want %#v, got %#v", wantALPN, ci.Negotiated.ALPN)
+				}
 			}
 		})
 	}
 }
 
-const (
-	expectedJSONBody = `{"given_cipher_suites":["TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256","TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384","TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256","TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384","TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256","TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256","TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA","TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA","TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA","TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA","TLS_RSA_WITH_AES_128_GCM_SHA256","TLS_RSA_WITH_AES_256_GCM_SHA384","TLS_RSA_WITH_AES_128_CBC_SHA","TLS_RSA_WITH_AES_256_CBC_SHA","TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA","TLS_RSA_WITH_3DES_EDE_CBC_SHA","TLS_AES_128_GCM_SHA256","TLS_CHACHA20_POLY1305_SHA256","TLS_AES_256_GCM_SHA384"],"ephemeral_keys_supported":true,"session_ticket_supported":false,"tls_compression_supported":false,"unknown_cipher_suite_supported":false,"beast_vuln":false,"able_to_detect_n_minus_one_splitting":false,"insecure_cipher_suites":{},"tls_version":"TLS 1.3","rating":"Probably Okay"}`
-)
+func TestJWKS(t *testing.T) {
+	jks, err := loadJWSKeySet("./config/development_signing_keys.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jksa := &jwsKeySetAtomic{}
+	jksa.Store(jks)
+	w := httptest.NewRecorder()
+	handleJWKS(jksa).ServeHTTP(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status code, want %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/jwk-set+json" {
+		t.Errorf("Content-Type, want application/jwk-set+json, got %s", ct)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &set)
+	if err != nil {
+		t.Fatalf("unable to parse body %#v: %s", w.Body.String(), err)
+	}
+	want := jwk{KTY: "OKP", CRV: "Ed25519", KID: "development", Alg: "EdDSA", Use: "sig"}
+	if len(set.Keys) != 1 {
+		t.Fatalf("want 1 key, got %d", len(set.Keys))
+	}
+	got := set.Keys[0]
+	got.X = ""
+	if got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
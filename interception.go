@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// uaBrowser is a browser we can recognize in a User-Agent, along with what
+// its ClientHellos are known to contain.
+type uaBrowser struct {
+	token       string // precedes the major version, like "Chrome/"
+	family      string // the knownClient family whose ClientHellos it sends
+	tls13Since  int    // the first major version to offer TLS 1.3
+	greaseSince int    // the first major version to send GREASE; 0 if none do
+}
+
+// uaBrowsers are checked in order, since Chromium-based browsers also claim
+// to be Chrome and Safari.
+var uaBrowsers = []uaBrowser{
+	{token: "Edg/", family: "Chrome", tls13Since: 79, greaseSince: 79},
+	{token: "Chrome/", family: "Chrome", tls13Since: 70, greaseSince: 55},
+	{token: "Firefox/", family: "Firefox", tls13Since: 63},
+	{token: "Version/", family: "Safari", tls13Since: 14, greaseSince: 11},
+}
+
+// uaSkipTokens mark browsers whose User-Agent claims a Chrome or Safari
+// version its TLS stack doesn't follow, like the EdgeHTML Edge and the iOS
+// browsers built on Apple's networking.
+var uaSkipTokens = []string{"Edge/", "CriOS/", "FxiOS/", "EdgiOS/"}
+
+// proxyHeaders are only added by proxies. This server terminates TLS itself,
+// so a request carrying one went through a proxy that decrypted it.
+var proxyHeaders = []string{"Via", "Forwarded", "X-Forwarded-For", "X-BlueCoat-Via", "X-Proxy-ID"}
+
+// parseBrowserUA returns the browser and major version the User-Agent
+// claims, if it's one we know the ClientHellos of.
+func parseBrowserUA(ua string) (uaBrowser, int, bool) {
+	for _, t := range uaSkipTokens {
+		if strings.Contains(ua, t) {
+			return uaBrowser{}, 0, false
+		}
+	}
+	for _, b := range uaBrowsers {
+		i := strings.Index(ua, b.token)
+		if i == -1 {
+			continue
+		}
+		if b.family == "Safari" && !strings.Contains(ua, "Safari/") {
+			continue
+		}
+		v := ua[i+len(b.token):]
+		if j := strings.IndexAny(v, ". ;)"); j != -1 {
+			v = v[:j]
+		}
+		major, err := strconv.Atoi(v)
+		if err != nil {
+			return uaBrowser{}, 0, false
+		}
+		return b, major, true
+	}
+	return uaBrowser{}, 0, false
+}
+
+// interceptionReasons returns why the connection looks like it was made by
+// a TLS-intercepting proxy rather than the client that sent the request. It
+// must be called after the client has been identified.
+func interceptionReasons(h http.Header, d *clientInfo, st *tls.ConnectionState) []string {
+	reasons := []string{}
+	for _, name := range proxyHeaders {
+		if h.Get(name) != "" {
+			reasons = append(reasons, fmt.Sprintf("The request carried a %s header, which proxies add after decrypting the connection.", name))
+		}
+	}
+	ic := d.IdentifiedClient
+	if ic != nil && ic.Middlebox {
+		reasons = append(reasons, fmt.Sprintf("The ClientHello matches %s, a known TLS-intercepting middlebox.", ic.Name))
+	}
+
+	b, major, ok := parseBrowserUA(h.Get("User-Agent"))
+	if !ok {
+		return reasons
+	}
+	if major >= b.tls13Since && maxOfferedVersion(st) < tls.VersionTLS13 {
+		reasons = append(reasons, fmt.Sprintf("The User-Agent claims %s %d, which offers TLS 1.3, but the ClientHello didn't.", b.family, major))
+	}
+	g := d.GREASE
+	sentGREASE := g.CipherSuites || g.Extensions || g.SupportedGroups || g.SignatureAlgorithms || g.SupportedVersions || g.ALPN
+	if b.greaseSince != 0 && major >= b.greaseSince && !sentGREASE {
+		reasons = append(reasons, fmt.Sprintf("The User-Agent claims %s %d, which sends GREASE values, but the ClientHello had none.", b.family, major))
+	}
+	if ic != nil && !ic.Middlebox && ic.Confidence >= ja3Confidence && ic.Family != b.family {
+		reasons = append(reasons, fmt.Sprintf("The User-Agent claims %s, but the ClientHello matches %s.", b.family, ic.Name))
+	}
+	return reasons
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	stdtls "crypto/tls"
+	"net/http"
+	"strings"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+const (
+	chromeUA  = "Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.6367.82 Mobile Safari/537.36"
+	firefoxUA = "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0"
+)
+
+func TestParseBrowserUA(t *testing.T) {
+	tests := []struct {
+		ua         string
+		wantFamily string
+		wantMajor  int
+	}{
+		{chromeUA, "Chrome", 124},
+		{firefoxUA, "Firefox", 125},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51", "Chrome", 124},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15", "Safari", 17},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1", "", 0},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19045", "", 0},
+		{"curl/8.5.0", "", 0},
+	}
+	for _, tt := range tests {
+		b, major, ok := parseBrowserUA(tt.ua)
+		if tt.wantFamily == "" {
+			if ok {
+				t.Errorf("%s: want no browser, got %s %d", tt.ua, b.family, major)
+			}
+			continue
+		}
+		if !ok || b.family != tt.wantFamily || major != tt.wantMajor {
+			t.Errorf("%s: want %s %d, got %s %d (ok: %t)", tt.ua, tt.wantFamily, tt.wantMajor, b.family, major, ok)
+		}
+	}
+}
+
+func TestInterceptionReasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		conn    func(t *testing.T) *conn
+		headers map[string]string
+		db      func(d *clientInfo) *fingerprintDB
+		want    []string
+	}{
+		{
+			name:    "curl",
+			conn:    func(t *testing.T) *conn { return connectStdlib(t, &stdtls.Config{}) },
+			headers: map[string]string{"User-Agent": "curl/8.5.0"},
+			want:    []string{},
+		},
+		{
+			name:    "Chrome UA without GREASE",
+			conn:    func(t *testing.T) *conn { return connectStdlib(t, &stdtls.Config{}) },
+			headers: map[string]string{"User-Agent": chromeUA},
+			want:    []string{"sends GREASE values"},
+		},
+		{
+			name:    "Firefox UA without TLS 1.3",
+			conn:    func(t *testing.T) *conn { return connect(t, &tls.Config{}) },
+			headers: map[string]string{"User-Agent": firefoxUA},
+			want:    []string{"offers TLS 1.3"},
+		},
+		{
+			name:    "proxy headers",
+			conn:    func(t *testing.T) *conn { return connectStdlib(t, &stdtls.Config{}) },
+			headers: map[string]string{"Via": "1.1 proxy.example.com", "X-Forwarded-For": "192.0.2.1"},
+			want:    []string{"a Via header", "a X-Forwarded-For header"},
+		},
+		{
+			name:    "Firefox UA with a Go ClientHello",
+			conn:    func(t *testing.T) *conn { return connectStdlib(t, &stdtls.Config{}) },
+			headers: map[string]string{"User-Agent": firefoxUA},
+			db: func(d *clientInfo) *fingerprintDB {
+				db, _ := newFingerprintDB([]knownClient{{Name: "Go crypto/tls", Family: "Go", JA4: []string{d.JA4}}})
+				return db
+			},
+			want: []string{"matches Go crypto/tls"},
+		},
+		{
+			name: "middlebox",
+			conn: func(t *testing.T) *conn { return connectStdlib(t, &stdtls.Config{}) },
+			db: func(d *clientInfo) *fingerprintDB {
+				db, _ := newFingerprintDB([]knownClient{{Name: "Example Proxy", Family: "Example Proxy", Middlebox: true, JA4: []string{d.JA4}}})
+				return db
+			},
+			want: []string{"known TLS-intercepting middlebox"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.conn(t)
+			d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+			if tt.db != nil {
+				d.IdentifiedClient = tt.db(d).identify(d.JA4, d.JA3Hash)
+			}
+			h := make(http.Header)
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			st := c.ConnectionState()
+			got := interceptionReasons(h, d, &st)
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d reasons, got %q", len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(got[i], w) {
+					t.Errorf("reason %d: want it to contain %q, got %q", i, w, got[i])
+				}
+			}
+		})
+	}
+}
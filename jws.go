@@ -0,0 +1,267 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// jwsKey is a private key API responses can be signed with, using the JWS
+// algorithm alg.
+type jwsKey struct {
+	kid    string
+	alg    string // "EdDSA", "ES256" or "ES384"
+	signer crypto.Signer
+}
+
+// jwsKeySet is the keys we publish at /.well-known/jwks.json, one of which
+// signs API responses. Keep the keys that used to sign listed for a while
+// after rotating, so that responses signed with them can still be verified.
+type jwsKeySet struct {
+	signing *jwsKey // nil if responses aren't signed
+	keys    []*jwsKey
+	jwks    []byte // the public keys, as a JWK Set
+}
+
+var emptyJWSKeySet = &jwsKeySet{jwks: []byte(`{"keys":[]}`)}
+
+// jwsKeysFile is the JSON format of the keys file:
+//
+//	{"signing_key": "2026-10", "keys": [{"kid": "2026-10", "file": "/secrets/jws-2026-10.pem"}]}
+//
+// The files hold PKCS #8 Ed25519 or ECDSA P-256 or P-384 private keys. To
+// rotate, add the new key, wait for verifiers' cached JWK Sets to expire,
+// and then make it the signing key.
+type jwsKeysFile struct {
+	SigningKey string           `json:"signing_key"`
+	Keys       []jwsKeyFileInfo `json:"keys"`
+}
+
+type jwsKeyFileInfo struct {
+	KID  string `json:"kid"`
+	File string `json:"file"`
+}
+
+// jwk is a public key in a JWK Set.
+type jwk struct {
+	KTY string `json:"kty"`
+	CRV string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	KID string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+type jwsHeader struct {
+	Alg string `json:"alg"`
+	KID string `json:"kid"`
+}
+
+func loadJWSKeySet(fp string) (*jwsKeySet, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open signing keys file %#v: %s", fp, err)
+	}
+	defer f.Close()
+	kf := &jwsKeysFile{}
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	err = dec.Decode(kf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signing keys file %#v: %s", fp, err)
+	}
+	var keys []*jwsKey
+	for _, ki := range kf.Keys {
+		b, err := ioutil.ReadFile(ki.File)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read signing key %#v: %s", ki.KID, err)
+		}
+		k, err := parseJWSKey(ki.KID, b)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse signing key %#v in %#v: %s", ki.KID, ki.File, err)
+		}
+		keys = append(keys, k)
+	}
+	ks, err := newJWSKeySet(kf.SigningKey, keys)
+	if err != nil {
+		return nil, fmt.Errorf("bad signing keys file %#v: %s", fp, err)
+	}
+	return ks, nil
+}
+
+// parseJWSKey parses a PEM-encoded PKCS #8 private key.
+func parseJWSKey(kid string, pemBytes []byte) (*jwsKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("no PEM PRIVATE KEY block found")
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	k := &jwsKey{kid: kid}
+	switch priv := priv.(type) {
+	case ed25519.PrivateKey:
+		k.alg = "EdDSA"
+		k.signer = priv
+	case *ecdsa.PrivateKey:
+		switch priv.Curve {
+		case elliptic.P256():
+			k.alg = "ES256"
+		case elliptic.P384():
+			k.alg = "ES384"
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve %s", priv.Curve.Params().Name)
+		}
+		k.signer = priv
+	default:
+		return nil, fmt.Errorf("unsupported key type %T; use Ed25519 or ECDSA", priv)
+	}
+	return k, nil
+}
+
+func newJWSKeySet(signingKID string, keys []*jwsKey) (*jwsKeySet, error) {
+	ks := &jwsKeySet{keys: keys}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: []jwk{}}
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		if k.kid == "" {
+			return nil, errors.New("a key is missing its kid")
+		}
+		if seen[k.kid] {
+			return nil, fmt.Errorf("kid %#v is used by more than one key", k.kid)
+		}
+		seen[k.kid] = true
+		if k.kid == signingKID {
+			ks.signing = k
+		}
+		set.Keys = append(set.Keys, k.jwk())
+	}
+	if signingKID != "" && ks.signing == nil {
+		return nil, fmt.Errorf("signing key %#v is not one of the keys", signingKID)
+	}
+	b, err := json.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+	ks.jwks = b
+	return ks, nil
+}
+
+func (k *jwsKey) jwk() jwk {
+	j := jwk{KID: k.kid, Alg: k.alg, Use: "sig"}
+	switch pub := k.signer.Public().(type) {
+	case ed25519.PublicKey:
+		j.KTY = "OKP"
+		j.CRV = "Ed25519"
+		j.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		j.KTY = "EC"
+		j.CRV = pub.Curve.Params().Name
+		j.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		j.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	}
+	return j
+}
+
+// sign returns a compact JWS of payload with the payload left out, as in
+// RFC 7515 appendix F, or "" if there's no signing key. To verify it, put
+// the base64url-encoded payload between its two dots.
+func (ks *jwsKeySet) sign(payload []byte) (string, error) {
+	k := ks.signing
+	if k == nil {
+		return "", nil
+	}
+	h, err := json.Marshal(jwsHeader{Alg: k.alg, KID: k.kid})
+	if err != nil {
+		return "", err
+	}
+	header := base64.RawURLEncoding.EncodeToString(h)
+	input := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sig, err := k.signJWS([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return header + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// signJWS signs input the way JWS does for k's algorithm, which, for ECDSA,
+// means r and s concatenated rather than the ASN.1 crypto.Signer returns.
+func (k *jwsKey) signJWS(input []byte) ([]byte, error) {
+	switch priv := k.signer.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(priv, input), nil
+	case *ecdsa.PrivateKey:
+		var digest []byte
+		if k.alg == "ES384" {
+			d := sha512.Sum384(input)
+			digest = d[:]
+		} else {
+			d := sha256.Sum256(input)
+			digest = d[:]
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest)
+		if err != nil {
+			return nil, err
+		}
+		size := (priv.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		return sig, nil
+	}
+	return nil, fmt.Errorf("unsupported signing key type %T", k.signer)
+}
+
+// handleJWKS serves the public keys responses are signed with.
+func handleJWKS(jksa *jwsKeySetAtomic) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jwks := jksa.Load().jwks
+		h := w.Header()
+		h.Set("Content-Type", "application/jwk-set+json")
+		h.Set("Cache-Control", "public, max-age=300")
+		h.Set("Access-Control-Allow-Origin", "*")
+		w.Write(jwks)
+	}
+}
+
+func reloadJWSKeySetForever(keysFile string, jksa *jwsKeySetAtomic, tick *time.Ticker) {
+	for range tick.C {
+		ks, err := loadJWSKeySet(keysFile)
+		if err != nil {
+			log.Printf("unable to reload signing keys at %#v: %s", keysFile, err)
+			continue
+		}
+		jksa.Store(ks)
+	}
+}
+
+type jwsKeySetAtomic atomic.Value
+
+func (a *jwsKeySetAtomic) Load() *jwsKeySet {
+	return (*atomic.Value)(a).Load().(*jwsKeySet)
+}
+
+func (a *jwsKeySetAtomic) Store(ks *jwsKeySet) {
+	(*atomic.Value)(a).Store(ks)
+}
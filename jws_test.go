@@ -0,0 +1,129 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/sullivanmatt/check.tls.support/verify"
+)
+
+func pkcs8PEM(t *testing.T, priv crypto.PrivateKey) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestJWSSign(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		kid     string
+		priv    crypto.PrivateKey
+		wantAlg string
+	}{
+		{"ed", edKey, "EdDSA"},
+		{"p256", p256Key, "ES256"},
+		{"p384", p384Key, "ES384"},
+	}
+	var keys []*jwsKey
+	for _, tt := range tests {
+		k, err := parseJWSKey(tt.kid, pkcs8PEM(t, tt.priv))
+		if err != nil {
+			t.Fatalf("%s: %s", tt.kid, err)
+		}
+		if k.alg != tt.wantAlg {
+			t.Errorf("%s: want alg %s, got %s", tt.kid, tt.wantAlg, k.alg)
+		}
+		keys = append(keys, k)
+	}
+	payload := []byte(`{"tls_version": "TLS 1.3"}`)
+	for _, tt := range tests {
+		ks, err := newJWSKeySet(tt.kid, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jws, err := ks.sign(payload)
+		if err != nil {
+			t.Fatalf("%s: sign: %s", tt.kid, err)
+		}
+		if err := verify.DetachedJWS(ks.jwks, jws, payload); err != nil {
+			t.Errorf("%s: %s", tt.kid, err)
+		}
+		if err := verify.DetachedJWS(ks.jwks, jws, []byte(`{"tls_version": "TLS 1.0"}`)); err == nil {
+			t.Errorf("%s: signature verified for a different payload", tt.kid)
+		}
+	}
+
+	jws, err := emptyJWSKeySet.sign(payload)
+	if jws != "" || err != nil {
+		t.Errorf("empty key set: want no signature, got %#v, %v", jws, err)
+	}
+}
+
+func TestLoadJWSKeySet(t *testing.T) {
+	ks, err := loadJWSKeySet("./config/development_signing_keys.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ks.signing == nil || ks.signing.kid != "development" {
+		t.Errorf("want the development key to sign, got %+v", ks.signing)
+	}
+}
+
+func TestBadJWSKeys(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = parseJWSKey("p224", pkcs8PEM(t, p224Key))
+	if err == nil || !strings.Contains(err.Error(), "unsupported ECDSA curve") {
+		t.Errorf("P-224: want an unsupported curve error, got %v", err)
+	}
+	_, err = parseJWSKey("cert", []byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"))
+	if err == nil {
+		t.Errorf("certificate: want an error")
+	}
+
+	k, err := parseJWSKey("ed", pkcs8PEM(t, edKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		signing string
+		keys    []*jwsKey
+		errStr  string
+	}{
+		{"ed", []*jwsKey{k, k}, "used by more than one key"},
+		{"other", []*jwsKey{k}, "not one of the keys"},
+		{"", []*jwsKey{{alg: k.alg, signer: k.signer}}, "missing its kid"},
+	}
+	for i, tt := range tests {
+		_, err := newJWSKeySet(tt.signing, tt.keys)
+		if err == nil || !strings.Contains(err.Error(), tt.errStr) {
+			t.Errorf("#%d: want error containing %q, got %v", i, tt.errStr, err)
+		}
+	}
+}
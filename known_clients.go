@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// How confident an identification is, by what matched. JA3 is a notch below
+// JA4 because it keeps the extension order, which some clients randomize, so
+// an exact JA3 match is rarer but no more telling. Matching all but one of
+// the three JA4 parts usually means a nearby version of the same client.
+const (
+	ja4Confidence        = 1.0
+	ja3Confidence        = 0.9
+	partialJA4Confidence = 0.5
+)
+
+// knownClient is an entry in the fingerprint database. Browsers should use
+// the families "Chrome", "Firefox" and "Safari", which are compared to the
+// User-Agent when looking for TLS interception.
+type knownClient struct {
+	Name      string   `json:"name"`      // shown to users, like "Chrome 12x on Android"
+	Family    string   `json:"family"`    // like "Chrome" or "Java HttpsURLConnection"
+	Version   string   `json:"version"`   // a version or range, like "8" or "120-129"
+	Platform  string   `json:"platform"`  // like "Android"; empty if it's the same everywhere
+	Middlebox bool     `json:"middlebox"` // a TLS-intercepting proxy rather than an end client
+	JA4       []string `json:"ja4"`
+	JA3Hashes []string `json:"ja3_hashes"`
+}
+
+// identifiedClient is the known client a connection looks like.
+type identifiedClient struct {
+	Name       string  `json:"name"`
+	Family     string  `json:"family"`
+	Version    string  `json:"version"`
+	Platform   string  `json:"platform,omitempty"`
+	Middlebox  bool    `json:"middlebox"`
+	Confidence float64 `json:"confidence"`
+	MatchedOn  string  `json:"matched_on"` // "ja4", "ja3" or "partial_ja4"
+}
+
+// fingerprintDB maps ClientHello fingerprints to the clients known to send
+// them.
+type fingerprintDB struct {
+	clients []knownClient
+	byJA4   map[string]int
+	byJA3   map[string]int
+}
+
+var emptyFingerprintDB = &fingerprintDB{}
+
+// fingerprintDBFile is the JSON fingerprint database format:
+//
+//	{"clients": [{"name": "Go crypto/tls", "family": "Go", "version": "1.27", "ja4": ["t13d..."]}]}
+type fingerprintDBFile struct {
+	Clients []knownClient `json:"clients"`
+}
+
+func loadFingerprintDB(fp string) (*fingerprintDB, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open fingerprint database %#v: %s", fp, err)
+	}
+	defer f.Close()
+	df := &fingerprintDBFile{}
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	err = dec.Decode(df)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse fingerprint database %#v: %s", fp, err)
+	}
+	db, err := newFingerprintDB(df.Clients)
+	if err != nil {
+		return nil, fmt.Errorf("bad fingerprint database %#v: %s", fp, err)
+	}
+	return db, nil
+}
+
+func newFingerprintDB(clients []knownClient) (*fingerprintDB, error) {
+	db := &fingerprintDB{
+		clients: clients,
+		byJA4:   make(map[string]int),
+		byJA3:   make(map[string]int),
+	}
+	for i, kc := range clients {
+		if kc.Name == "" || kc.Family == "" {
+			return nil, fmt.Errorf("client %d is missing a name or family", i)
+		}
+		if len(kc.JA4) == 0 && len(kc.JA3Hashes) == 0 {
+			return nil, fmt.Errorf("client %#v has no fingerprints", kc.Name)
+		}
+		for _, fp := range kc.JA4 {
+			if len(strings.Split(fp, "_")) != 3 {
+				return nil, fmt.Errorf("client %#v has malformed JA4 fingerprint %#v", kc.Name, fp)
+			}
+			if j, ok := db.byJA4[fp]; ok {
+				return nil, fmt.Errorf("JA4 fingerprint %#v is listed for both %#v and %#v", fp, clients[j].Name, kc.Name)
+			}
+			db.byJA4[fp] = i
+		}
+		for _, h := range kc.JA3Hashes {
+			h = strings.ToLower(h)
+			if j, ok := db.byJA3[h]; ok {
+				return nil, fmt.Errorf("JA3 hash %#v is listed for both %#v and %#v", h, clients[j].Name, kc.Name)
+			}
+			db.byJA3[h] = i
+		}
+	}
+	return db, nil
+}
+
+// identify returns the known client the fingerprints best match, or nil if
+// none do. When several clients partially match, the first listed wins.
+func (db *fingerprintDB) identify(ja4, ja3Hash string) *identifiedClient {
+	if i, ok := db.byJA4[ja4]; ok {
+		return db.clients[i].identified(ja4Confidence, "ja4")
+	}
+	if i, ok := db.byJA3[ja3Hash]; ok {
+		return db.clients[i].identified(ja3Confidence, "ja3")
+	}
+	parts := strings.Split(ja4, "_")
+	for _, kc := range db.clients {
+		for _, fp := range kc.JA4 {
+			same := 0
+			for j, p := range strings.Split(fp, "_") {
+				if j < len(parts) && parts[j] == p {
+					same++
+				}
+			}
+			if same == 2 {
+				return kc.identified(partialJA4Confidence, "partial_ja4")
+			}
+		}
+	}
+	return nil
+}
+
+func (kc knownClient) identified(confidence float64, matchedOn string) *identifiedClient {
+	return &identifiedClient{
+		Name:       kc.Name,
+		Family:     kc.Family,
+		Version:    kc.Version,
+		Platform:   kc.Platform,
+		Middlebox:  kc.Middlebox,
+		Confidence: confidence,
+		MatchedOn:  matchedOn,
+	}
+}
+
+func reloadFingerprintDBForever(dbFile string, fdba *fingerprintDBAtomic, tick *time.Ticker) {
+	for range tick.C {
+		db, err := loadFingerprintDB(dbFile)
+		if err != nil {
+			log.Printf("unable to reload fingerprint database at %#v: %s", dbFile, err)
+			continue
+		}
+		fdba.Store(db)
+	}
+}
+
+type fingerprintDBAtomic atomic.Value
+
+func (a *fingerprintDBAtomic) Load() *fingerprintDB {
+	return (*atomic.Value)(a).Load().(*fingerprintDB)
+}
+
+func (a *fingerprintDBAtomic) Store(db *fingerprintDB) {
+	(*atomic.Value)(a).Store(db)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	stdtls "crypto/tls"
+	"strings"
+	"testing"
+)
+
+func TestIdentifyKnownClient(t *testing.T) {
+	c := connectStdlib(t, &stdtls.Config{NextProtos: []string{"h2", "http/1.1"}})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if d.IdentifiedClient != nil {
+		t.Errorf("empty database: want no identified client, got %+v", d.IdentifiedClient)
+	}
+
+	db, err := newFingerprintDB([]knownClient{
+		{Name: "Go net/http", Family: "Go", Version: "test", JA4: []string{d.JA4}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = connectStdlib(t, &stdtls.Config{NextProtos: []string{"h2", "http/1.1"}})
+	d = pullClientInfo(c, defaultRatingPolicy, db)
+	if d.IdentifiedClient == nil {
+		t.Fatalf("want Go net/http, got no identified client")
+	}
+	if d.IdentifiedClient.Name != "Go net/http" || d.IdentifiedClient.Confidence != ja4Confidence || d.IdentifiedClient.MatchedOn != "ja4" {
+		t.Errorf("want an exact JA4 match of Go net/http, got %+v", d.IdentifiedClient)
+	}
+
+	// Without ALPN only the first part of the JA4 fingerprint changes.
+	c = connectStdlib(t, &stdtls.Config{})
+	d = pullClientInfo(c, defaultRatingPolicy, db)
+	if d.IdentifiedClient == nil || d.IdentifiedClient.MatchedOn != "partial_ja4" || d.IdentifiedClient.Confidence != partialJA4Confidence {
+		t.Errorf("without ALPN: want a partial JA4 match, got %+v", d.IdentifiedClient)
+	}
+}
+
+func TestFingerprintDBIdentify(t *testing.T) {
+	db, err := newFingerprintDB([]knownClient{
+		{Name: "Example 1", Family: "Example", Version: "1", JA4: []string{"t13d1516h2_aaaaaaaaaaaa_bbbbbbbbbbbb"}},
+		{Name: "Example 2", Family: "Example", Version: "2", JA3Hashes: []string{"0123456789ABCDEF0123456789ABCDEF"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ja4, ja3Hash string
+		wantName     string
+		wantMatch    string
+	}{
+		{"t13d1516h2_aaaaaaaaaaaa_bbbbbbbbbbbb", "", "Example 1", "ja4"},
+		{"t13d1516h2_cccccccccccc_dddddddddddd", "0123456789abcdef0123456789abcdef", "Example 2", "ja3"},
+		{"t13d1517h2_aaaaaaaaaaaa_bbbbbbbbbbbb", "", "Example 1", "partial_ja4"},
+		{"t13d1516h2_cccccccccccc_dddddddddddd", "", "", ""},
+	}
+	for _, tt := range tests {
+		got := db.identify(tt.ja4, tt.ja3Hash)
+		if tt.wantName == "" {
+			if got != nil {
+				t.Errorf("%s: want no match, got %+v", tt.ja4, got)
+			}
+			continue
+		}
+		if got == nil || got.Name != tt.wantName || got.MatchedOn != tt.wantMatch {
+			t.Errorf("%s: want %s matched on %s, got %+v", tt.ja4, tt.wantName, tt.wantMatch, got)
+		}
+	}
+}
+
+func TestLoadFingerprintDB(t *testing.T) {
+	db, err := loadFingerprintDB("./config/fingerprints.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.clients) == 0 {
+		t.Errorf("no clients loaded")
+	}
+}
+
+func TestBadFingerprintDB(t *testing.T) {
+	tests := []struct {
+		clients []knownClient
+		errStr  string
+	}{
+		{[]knownClient{{Family: "Go", JA4: []string{"a_b_c"}}}, "missing a name or family"},
+		{[]knownClient{{Name: "Go", Family: "Go"}}, "has no fingerprints"},
+		{[]knownClient{{Name: "Go", Family: "Go", JA4: []string{"a_b"}}}, "malformed JA4"},
+		{
+			[]knownClient{
+				{Name: "Go", Family: "Go", JA3Hashes: []string{"abc"}},
+				{Name: "Java", Family: "Java", JA3Hashes: []string{"ABC"}},
+			},
+			`listed for both "Go" and "Java"`,
+		},
+	}
+	for i, tt := range tests {
+		_, err := newFingerprintDB(tt.clients)
+		if err == nil || !strings.Contains(err.Error(), tt.errStr) {
+			t.Errorf("#%d: want error containing %q, got %v", i, tt.errStr, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import tls "github.com/sullivanmatt/check.tls.support/tls110"
+
+// negotiatedInfo is what was actually agreed on for this connection, as
+// opposed to everything the client offered.
+type negotiatedInfo struct {
+	Version         string `json:"version"`
+	CipherSuite     string `json:"cipher_suite"`
+	Curve           string `json:"curve,omitempty"`            // empty unless ECDHE was used
+	DHGroupBits     int    `json:"dh_group_bits,omitempty"`    // zero unless DHE was used
+	SignatureScheme string `json:"signature_scheme,omitempty"` // empty for RSA key exchange and before TLS 1.2
+	ALPN            string `json:"alpn,omitempty"`
+	DidResume       bool   `json:"did_resume"`
+}
+
+func pullNegotiatedInfo(st *tls.ConnectionState) negotiatedInfo {
+	n := negotiatedInfo{
+		Version:     actualSupportedVersions[st.Version],
+		CipherSuite: cipherSuiteName(st.CipherSuite),
+		DHGroupBits: st.DHGroupBits,
+		ALPN:        st.NegotiatedProtocol,
+		DidResume:   st.DidResume,
+	}
+	if n.Version == "" {
+		n.Version = "an unknown version of SSL/TLS"
+	}
+	if st.CurveID != 0 {
+		n.Curve = groupName(st.CurveID)
+	}
+	if st.SignatureScheme != 0 {
+		n.SignatureScheme = signatureSchemeName(st.SignatureScheme)
+	}
+	return n
+}
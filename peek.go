@@ -0,0 +1,324 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	stdtls "crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// peekConn is a server connection that reports on the client's ClientHello
+// like tls110 does. crypto/tls doesn't expose the ClientHello, so the
+// connection reads it off the wire with tls110 first and then replays it into
+// crypto/tls. ClientHellos that crypto/tls would refuse or can't report on
+// are replayed into tls110 instead (see servedByTLS110).
+type peekConn struct {
+	net.Conn
+	stdlibConf *stdtls.Config
+	legacyConf *tls.Config
+
+	// startMu is held while the ClientHello is read, and mu while the
+	// fields below are read or set.
+	startMu sync.Mutex
+	mu      sync.Mutex
+	replay  *replayConn
+	stdlib  *stdtls.Conn
+	legacy  *tls.Conn
+	err     error
+}
+
+func newPeekConn(c net.Conn, stdlibConf *stdtls.Config, legacyConf *tls.Config) *peekConn {
+	return &peekConn{
+		Conn:       c,
+		stdlibConf: stdlibConf,
+		legacyConf: legacyConf,
+	}
+}
+
+// handshakeConn is the part of *stdtls.Conn and *tls.Conn peekConn uses.
+type handshakeConn interface {
+	net.Conn
+	Handshake() error
+}
+
+// active returns the TLS connection serving c, or nil if the ClientHello
+// hasn't been read yet.
+func (c *peekConn) active() handshakeConn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.legacy != nil {
+		return c.legacy
+	}
+	if c.stdlib != nil {
+		return c.stdlib
+	}
+	return nil
+}
+
+// start reads the ClientHello, if it hasn't been already, and returns the
+// TLS connection chosen to serve it.
+func (c *peekConn) start() (handshakeConn, error) {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+	c.mu.Lock()
+	err := c.err
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if tc := c.active(); tc != nil {
+		return tc, nil
+	}
+	raw, hello, err := tls.ReadClientHello(c.Conn)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.err = err
+		return nil, err
+	}
+	c.replay = &replayConn{Conn: c.Conn, buf: raw, hello: hello}
+	if servedByTLS110(&hello, c.stdlibConf) {
+		c.legacy = tls.Server(c.replay, c.legacyConf)
+		return c.legacy, nil
+	}
+	c.stdlib = stdtls.Server(c.replay, c.stdlibConf)
+	return c.stdlib, nil
+}
+
+func (c *peekConn) Handshake() error {
+	tc, err := c.start()
+	if err != nil {
+		return err
+	}
+	return tc.Handshake()
+}
+
+func (c *peekConn) Read(b []byte) (int, error) {
+	tc, err := c.start()
+	if err != nil {
+		return 0, err
+	}
+	return tc.Read(b)
+}
+
+func (c *peekConn) Write(b []byte) (int, error) {
+	tc, err := c.start()
+	if err != nil {
+		return 0, err
+	}
+	return tc.Write(b)
+}
+
+func (c *peekConn) Close() error {
+	if tc := c.active(); tc != nil {
+		return tc.Close()
+	}
+	return c.Conn.Close()
+}
+
+// ConnectionState returns the state of the connection in tls110's terms.
+// crypto/tls doesn't detect 1/n-1 record splitting, so the splitting fields
+// are always zero when it serves the connection, and it has no DHE, so
+// DHGroupBits is too.
+func (c *peekConn) ConnectionState() tls.ConnectionState {
+	c.mu.Lock()
+	legacy, stdlib, replay := c.legacy, c.stdlib, c.replay
+	c.mu.Unlock()
+	if legacy != nil {
+		return legacy.ConnectionState()
+	}
+	if stdlib == nil {
+		return tls.ConnectionState{}
+	}
+	cs := stdlib.ConnectionState()
+	var st tls.ConnectionState
+	if cs.HandshakeComplete {
+		st = replay.hello
+		st.Version = cs.Version
+		st.NegotiatedProtocol = cs.NegotiatedProtocol
+		st.DidResume = cs.DidResume
+		st.NegotiatedProtocolIsMutual = true
+		st.CipherSuite = cs.CipherSuite
+		st.CurveID = tls.CurveID(cs.CurveID)
+		if cs.Version >= tls.VersionTLS12 {
+			st.SignatureScheme = tls.SignatureScheme(atomic.LoadUint32(&replay.signatureScheme))
+		}
+		st.PeerCertificates = cs.PeerCertificates
+		st.VerifiedChains = cs.VerifiedChains
+		st.SignedCertificateTimestamps = cs.SignedCertificateTimestamps
+		st.OCSPResponse = cs.OCSPResponse
+		st.TLSUnique = cs.TLSUnique
+	}
+	st.HandshakeComplete = cs.HandshakeComplete
+	st.ServerName = cs.ServerName
+	return st
+}
+
+// servedByTLS110 reports whether the client that sent hello has to be served
+// by tls110 rather than crypto/tls. That's the case when it sent
+// TLS_FALLBACK_SCSV, which crypto/tls aborts the handshake over instead of
+// letting it be reported, or when it can't negotiate anything crypto/tls
+// does with conf, like SSL 3.0 or DHE.
+func servedByTLS110(hello *tls.ConnectionState, conf *stdtls.Config) bool {
+	if hello.ClientFallbackSCSV {
+		return true
+	}
+	for _, v := range hello.SupportedVersions {
+		if v == tls.VersionTLS13 {
+			return false
+		}
+	}
+	if hello.ClientVersion < tls.VersionTLS10 {
+		return true
+	}
+	for _, id := range hello.ClientCipherSuites {
+		for _, s := range conf.CipherSuites {
+			if id == s {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// replayConn hands back the ClientHello bytes already read off of its
+// net.Conn before reading any more.
+type replayConn struct {
+	net.Conn
+	buf   []byte
+	hello tls.ConnectionState
+	// signatureScheme is the tls.SignatureScheme crypto/tls signed the
+	// handshake with, recorded by schemeRecorder.
+	signatureScheme uint32
+}
+
+func (c *replayConn) Read(b []byte) (int, error) {
+	if len(c.buf) != 0 {
+		n := copy(b, c.buf)
+		c.buf = c.buf[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
+
+// schemeRecorder wraps a certificate's private key to record which signature
+// scheme crypto/tls signs the handshake with, since its ConnectionState
+// doesn't say.
+type schemeRecorder struct {
+	crypto.Signer
+	conn *replayConn
+}
+
+func (s *schemeRecorder) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	atomic.StoreUint32(&s.conn.signatureScheme, uint32(signatureSchemeFor(s.Public(), opts)))
+	return s.Signer.Sign(rand, digest, opts)
+}
+
+// Decrypt lets crypto/tls use RSA key exchange with the wrapped key.
+func (s *schemeRecorder) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	d, ok := s.Signer.(crypto.Decrypter)
+	if !ok {
+		return nil, errors.New("certificate's private key can't decrypt")
+	}
+	return d.Decrypt(rand, msg, opts)
+}
+
+// signatureSchemeFor returns the TLS 1.2 and later signature scheme that a
+// signature by pub's key with opts is made with, or 0 if there isn't one.
+func signatureSchemeFor(pub crypto.PublicKey, opts crypto.SignerOpts) tls.SignatureScheme {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			switch opts.HashFunc() {
+			case crypto.SHA256:
+				return tls.PSSWithSHA256
+			case crypto.SHA384:
+				return tls.PSSWithSHA384
+			case crypto.SHA512:
+				return tls.PSSWithSHA512
+			}
+			return 0
+		}
+		switch opts.HashFunc() {
+		case crypto.SHA1:
+			return tls.PKCS1WithSHA1
+		case crypto.SHA256:
+			return tls.PKCS1WithSHA256
+		case crypto.SHA384:
+			return tls.PKCS1WithSHA384
+		case crypto.SHA512:
+			return tls.PKCS1WithSHA512
+		}
+	case *ecdsa.PublicKey:
+		switch opts.HashFunc() {
+		case crypto.SHA1:
+			return tls.ECDSAWithSHA1
+		case crypto.SHA256:
+			return tls.ECDSAWithP256AndSHA256
+		case crypto.SHA384:
+			return tls.ECDSAWithP384AndSHA384
+		case crypto.SHA512:
+			return tls.ECDSAWithP521AndSHA512
+		}
+	case ed25519.PublicKey:
+		return 0x0807 // ed25519, which tls110 predates
+	}
+	return 0
+}
+
+// makeStdlibTLSConfig returns a crypto/tls config that serves like base,
+// minus what crypto/tls can't do: SSL 3.0, DHE, and reporting
+// TLS_FALLBACK_SCSV rather than rejecting it. peekConn hands the clients
+// that need those to tls110.
+func makeStdlibTLSConfig(base *tls.Config) *stdtls.Config {
+	implemented := make(map[uint16]bool)
+	for _, cs := range stdtls.CipherSuites() {
+		implemented[cs.ID] = true
+	}
+	for _, cs := range stdtls.InsecureCipherSuites() {
+		implemented[cs.ID] = true
+	}
+	var suites []uint16
+	for _, id := range base.CipherSuites {
+		if implemented[id] {
+			suites = append(suites, id)
+		}
+	}
+	var curves []stdtls.CurveID
+	for _, id := range base.CurvePreferences {
+		curves = append(curves, stdtls.CurveID(id))
+	}
+	return &stdtls.Config{
+		GetCertificate: func(hi *stdtls.ClientHelloInfo) (*stdtls.Certificate, error) {
+			cert, err := base.GetCertificate(&tls.ClientHelloInfo{ServerName: hi.ServerName})
+			if err != nil || cert == nil {
+				return nil, err
+			}
+			priv := cert.PrivateKey
+			if signer, ok := priv.(crypto.Signer); ok {
+				if rc, ok := hi.Conn.(*replayConn); ok {
+					priv = &schemeRecorder{Signer: signer, conn: rc}
+				}
+			}
+			return &stdtls.Certificate{
+				Certificate:                 cert.Certificate,
+				PrivateKey:                  priv,
+				OCSPStaple:                  cert.OCSPStaple,
+				SignedCertificateTimestamps: cert.SignedCertificateTimestamps,
+				Leaf:                        cert.Leaf,
+			}, nil
+		},
+		NextProtos:       base.NextProtos,
+		MinVersion:       stdtls.VersionTLS10,
+		CurvePreferences: curves,
+		CipherSuites:     suites,
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	stdtls "crypto/tls"
+	"crypto/x509"
+	"expvar"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// connectStdlibBackend is like connectWith but against a server using
+// crypto/tls with serverConf's settings.
+func connectStdlibBackend(t *testing.T, dial func(d *net.Dialer, addr string) (net.Conn, error)) *conn {
+	nl, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Listen: %s", err)
+	}
+	li := newStdlibListener(nl, serverConf, new(expvar.Map).Init())
+	return connectListener(t, li, dial)
+}
+
+func TestStdlibBackend(t *testing.T) {
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCA)
+	tls110Dial := func(conf *tls.Config) func(d *net.Dialer, addr string) (net.Conn, error) {
+		return func(d *net.Dialer, addr string) (net.Conn, error) {
+			conf.ServerName = "localhost"
+			conf.Time = func() time.Time { return devCertTime }
+			conf.RootCAs = roots
+			return tls.DialWithDialer(d, "tcp", addr, conf)
+		}
+	}
+	tests := []struct {
+		name        string
+		dial        func(d *net.Dialer, addr string) (net.Conn, error)
+		wantVersion string
+		// wantTLS110 is whether peekConn should hand the client to tls110.
+		wantTLS110 bool
+	}{
+		{
+			name: "crypto/tls client",
+			dial: func(d *net.Dialer, addr string) (net.Conn, error) {
+				conf := &stdtls.Config{
+					ServerName: "localhost",
+					Time:       func() time.Time { return devCertTime },
+					RootCAs:    roots,
+					NextProtos: []string{"h2", "http/1.1"},
+				}
+				return stdtls.DialWithDialer(d, "tcp", addr, conf)
+			},
+			wantVersion: "TLS 1.3",
+		},
+		{
+			name: "tls110 client",
+			dial: tls110Dial(&tls.Config{
+				MaxVersion:   tls.VersionTLS11,
+				CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA},
+			}),
+			wantVersion: "TLS 1.1",
+		},
+		{
+			name: "TLS 1.2 tls110 client",
+			dial: tls110Dial(&tls.Config{
+				MaxVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			}),
+			wantVersion: "TLS 1.2",
+		},
+		{
+			name: "TLS 1.0 CBC crypto/tls client",
+			dial: func(d *net.Dialer, addr string) (net.Conn, error) {
+				conf := &stdtls.Config{
+					ServerName:   "localhost",
+					Time:         func() time.Time { return devCertTime },
+					RootCAs:      roots,
+					MinVersion:   stdtls.VersionTLS10,
+					MaxVersion:   stdtls.VersionTLS10,
+					CipherSuites: []uint16{stdtls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA},
+				}
+				return stdtls.DialWithDialer(d, "tcp", addr, conf)
+			},
+			wantVersion: "TLS 1.0",
+		},
+		{
+			name: "TLS 1.0 CBC tls110 client",
+			dial: tls110Dial(&tls.Config{
+				MaxVersion:   tls.VersionTLS10,
+				CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA},
+			}),
+			wantVersion: "TLS 1.0",
+		},
+		{
+			name: "fallback SCSV",
+			dial: tls110Dial(&tls.Config{
+				MaxVersion:   tls.VersionTLS11,
+				CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_FALLBACK_SCSV},
+			}),
+			wantVersion: "TLS 1.1",
+			wantTLS110:  true,
+		},
+		{
+			name: "DHE-only client",
+			dial: tls110Dial(&tls.Config{
+				MaxVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256},
+			}),
+			wantVersion: "TLS 1.2",
+			wantTLS110:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdlib := connectStdlibBackend(t, tt.dial)
+			pc, ok := stdlib.tlsConn.(*peekConn)
+			if !ok {
+				t.Fatalf("want a *peekConn, got %T", stdlib.tlsConn)
+			}
+			if gotTLS110 := pc.legacy != nil; gotTLS110 != tt.wantTLS110 {
+				t.Errorf("served by tls110: want %t, got %t", tt.wantTLS110, gotTLS110)
+			}
+			legacy := connectWith(t, serverConf, tt.dial)
+			got := pullClientInfo(stdlib, defaultRatingPolicy, emptyFingerprintDB)
+			want := pullClientInfo(legacy, defaultRatingPolicy, emptyFingerprintDB)
+
+			if got.TLSVersion != tt.wantVersion {
+				t.Errorf("TLSVersion: want %s, got %s", tt.wantVersion, got.TLSVersion)
+			}
+			if got.Negotiated.CipherSuite != want.Negotiated.CipherSuite {
+				t.Errorf("negotiated cipher suite: want %s like tls110, got %s", want.Negotiated.CipherSuite, got.Negotiated.CipherSuite)
+			}
+			if got.Negotiated.Curve == "" && want.Negotiated.Curve != "" {
+				t.Errorf("no negotiated curve")
+			}
+			if got.Negotiated.SignatureScheme != want.Negotiated.SignatureScheme {
+				t.Errorf("negotiated signature scheme: want %#v like tls110, got %#v", want.Negotiated.SignatureScheme, got.Negotiated.SignatureScheme)
+			}
+			if got.Negotiated.DHGroupBits != want.Negotiated.DHGroupBits {
+				t.Errorf("negotiated DH group bits: want %d like tls110, got %d", want.Negotiated.DHGroupBits, got.Negotiated.DHGroupBits)
+			}
+			// crypto/tls can't tell whether the client splits its records,
+			// so it mustn't be reported as BEAST vulnerable.
+			if !tt.wantTLS110 && (got.AbleToDetectNMinusOneSplitting || got.BEASTVuln) {
+				t.Errorf("crypto/tls: want neither AbleToDetectNMinusOneSplitting nor BEASTVuln, got %t and %t", got.AbleToDetectNMinusOneSplitting, got.BEASTVuln)
+			}
+			// The ClientHellos differ in their random values and key shares,
+			// but everything summarizing them should match.
+			if len(got.ClientHello) == 0 {
+				t.Errorf("no raw ClientHello")
+			}
+			compare := []struct {
+				field     string
+				got, want interface{}
+			}{
+				{"JA3", got.JA3, want.JA3},
+				{"JA4", got.JA4, want.JA4},
+				{"SupportedCipherSuites", got.SupportedCipherSuites, want.SupportedCipherSuites},
+				{"OfferedGroups", got.OfferedGroups, want.OfferedGroups},
+				{"SignatureAlgorithms", got.SignatureAlgorithms, want.SignatureAlgorithms},
+				{"GREASE", got.GREASE, want.GREASE},
+				{"BrokenCipherSuites", got.BrokenCipherSuites, want.BrokenCipherSuites},
+				{"FallbackSCSVSent", got.FallbackSCSVSent, want.FallbackSCSVSent},
+				{"BEASTVuln", got.BEASTVuln, want.BEASTVuln},
+				{"Vulnerabilities", got.Vulnerabilities, want.Vulnerabilities},
+				{"Rating", got.Rating, want.Rating},
+			}
+			for _, c := range compare {
+				if !reflect.DeepEqual(c.got, c.want) {
+					t.Errorf("%s: want %v like tls110, got %v", c.field, c.want, c.got)
+				}
+			}
+		})
+	}
+}
+
+func TestServedByTLS110(t *testing.T) {
+	conf := makeStdlibTLSConfig(serverConf)
+	tests := []struct {
+		name  string
+		hello tls.ConnectionState
+		want  bool
+	}{
+		{"TLS 1.3", tls.ConnectionState{ClientVersion: tls.VersionTLS12, SupportedVersions: []uint16{tls.VersionTLS13, tls.VersionTLS12}, ClientCipherSuites: []uint16{tls.TLS_AES_128_GCM_SHA256}}, false},
+		{"TLS 1.2", tls.ConnectionState{ClientVersion: tls.VersionTLS12, ClientCipherSuites: []uint16{tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_AES_128_CBC_SHA}}, false},
+		{"SSL 3.0", tls.ConnectionState{ClientVersion: tls.VersionSSL30, ClientCipherSuites: []uint16{tls.TLS_RSA_WITH_AES_128_CBC_SHA}}, true},
+		{"DHE only", tls.ConnectionState{ClientVersion: tls.VersionTLS12, ClientCipherSuites: []uint16{tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256}}, true},
+		{"fallback SCSV", tls.ConnectionState{ClientVersion: tls.VersionTLS11, ClientCipherSuites: []uint16{tls.TLS_RSA_WITH_AES_128_CBC_SHA, tls.TLS_FALLBACK_SCSV}, ClientFallbackSCSV: true}, true},
+	}
+	for _, tt := range tests {
+		if got := servedByTLS110(&tt.hello, conf); got != tt.want {
+			t.Errorf("%s: want %t, got %t", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestStdlibBackendBadClientHello(t *testing.T) {
+	nl, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	li := newStdlibListener(nl, serverConf, new(expvar.Map).Init())
+	defer li.Close()
+	errCh := make(chan error, 1)
+	go func() {
+		c, err := li.Accept()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer c.Close()
+		c.SetDeadline(time.Now().Add(time.Second))
+		errCh <- c.(*conn).handshake()
+	}()
+	c, err := net.Dial("tcp", li.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	_, err = c.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = <-errCh
+	if _, ok := err.(tls.RecordHeaderError); !ok {
+		t.Errorf("want a RecordHeaderError, got %#v", err)
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+type severity string
+
+const (
+	severityLow      severity = "low"
+	severityMedium   severity = "medium"
+	severityHigh     severity = "high"
+	severityCritical severity = "critical"
+)
+
+var severities = map[severity]bool{
+	severityLow:      true,
+	severityMedium:   true,
+	severityHigh:     true,
+	severityCritical: true,
+}
+
+// ratingOrder ranks ratings from best to worst.
+var ratingOrder = map[rating]int{
+	good:       0,
+	improvable: 1,
+	bad:        2,
+}
+
+var ratingScores = map[rating]rating_score{
+	good:       good_score,
+	improvable: improvable_score,
+	bad:        bad_score,
+}
+
+// ratingRule is a named check of a client. A client that fails an enabled
+// rule is rated no better than the rule's Rating.
+type ratingRule struct {
+	Name        string
+	Description string
+	Severity    severity
+	Rating      rating
+	Disabled    bool
+
+	fails func(d *clientInfo) bool
+}
+
+// ratingRuleResult is a failed rule, as reported to the client.
+type ratingRuleResult struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Severity    severity `json:"severity"`
+	Rating      rating   `json:"rating"`
+}
+
+// defaultRatingRules are howsmyssl's own grading, used for any rule a policy
+// file doesn't override.
+var defaultRatingRules = []ratingRule{
+	{
+		Name:        "no_ephemeral_keys",
+		Description: "The client supports no cipher suites with ephemeral keys.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return !d.EphemeralKeysSupported },
+	},
+	{
+		Name:        "tls_1_2",
+		Description: "The client used TLS 1.2 rather than TLS 1.3.",
+		Severity:    severityLow,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return d.version == tls.VersionTLS12 },
+	},
+	{
+		Name:        "no_session_tickets",
+		Description: "The client doesn't support session tickets.",
+		Severity:    severityLow,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return !d.SessionTicketsSupported },
+	},
+	{
+		Name:        "no_post_quantum",
+		Description: "The client offered no post-quantum key exchange groups.",
+		Severity:    severityLow,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return !d.PostQuantumReady },
+	},
+	{
+		// TLS 1.3 builds both it and secure renegotiation into the
+		// handshake, so only older versions are exposed to the triple
+		// handshake and renegotiation attacks.
+		Name:        "no_extended_master_secret",
+		Description: "The client used TLS 1.2 or older without the extended master secret extension.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails: func(d *clientInfo) bool {
+			return d.version != tls.VersionTLS13 && !d.ExtendedMasterSecretSupported
+		},
+	},
+	{
+		Name:        "no_secure_renegotiation",
+		Description: "The client used TLS 1.2 or older without secure renegotiation.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails: func(d *clientInfo) bool {
+			return d.version != tls.VersionTLS13 && !d.SecureRenegotiationSupported
+		},
+	},
+	{
+		Name:        "weak_signature_algorithms",
+		Description: "The client offered weak signature algorithms.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return len(d.WeakSignatureAlgorithms) != 0 },
+	},
+	{
+		Name:        "low_security_group",
+		Description: "The client offered key exchange groups with less than 128 bits of security.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return d.LowSecurityGroupSupported },
+	},
+	{
+		Name:        "fallback_scsv",
+		Description: "The client fell back to an older TLS version after a failed handshake.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return d.FallbackSCSVSent },
+	},
+	{
+		Name:        "weak_dh_group",
+		Description: "The client accepted 1024-bit Diffie-Hellman parameters.",
+		Severity:    severityMedium,
+		Rating:      improvable,
+		fails:       func(d *clientInfo) bool { return d.WeakDHGroupAccepted },
+	},
+	{
+		Name:        "tls_compression",
+		Description: "The client supports TLS compression, exposing it to the CRIME attack.",
+		Severity:    severityHigh,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return d.TLSCompressionSupported },
+	},
+	{
+		Name:        "unknown_cipher_suite",
+		Description: "The client supports cipher suites of unknown safety.",
+		Severity:    severityHigh,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return d.UnknownCipherSuiteSupported },
+	},
+	{
+		Name:        "beast",
+		Description: "The client is vulnerable to the BEAST attack.",
+		Severity:    severityHigh,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return d.BEASTVuln },
+	},
+	{
+		Name:        "broken_cipher_suites",
+		Description: "The client supports broken cipher suites.",
+		Severity:    severityCritical,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return len(d.BrokenCipherSuites) != 0 },
+	},
+	{
+		Name:        "md5_signature_algorithms",
+		Description: "The client offered signature algorithms using MD5.",
+		Severity:    severityHigh,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return d.md5SigSupported },
+	},
+	{
+		Name:        "logjam",
+		Description: "The client accepted Diffie-Hellman parameters smaller than 1024 bits.",
+		Severity:    severityCritical,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return d.LogjamVuln },
+	},
+	{
+		Name:        "tls_1_1_or_older",
+		Description: "The client used TLS 1.1 or older.",
+		Severity:    severityHigh,
+		Rating:      bad,
+		fails:       func(d *clientInfo) bool { return d.version <= tls.VersionTLS11 },
+	},
+}
+
+// ratingPolicy is the set of rules clients are rated with.
+type ratingPolicy struct {
+	rules []ratingRule
+}
+
+var defaultRatingPolicy = &ratingPolicy{rules: defaultRatingRules}
+
+// rate sets d's rating to the worst rating of the enabled rules it fails,
+// and lists those rules.
+func (rp *ratingPolicy) rate(d *clientInfo) {
+	d.Rating = good
+	d.FailedRatingRules = []ratingRuleResult{}
+	for _, r := range rp.rules {
+		if r.Disabled || !r.fails(d) {
+			continue
+		}
+		d.FailedRatingRules = append(d.FailedRatingRules, ratingRuleResult{
+			Name:        r.Name,
+			Description: r.Description,
+			Severity:    r.Severity,
+			Rating:      r.Rating,
+		})
+		if ratingOrder[r.Rating] > ratingOrder[d.Rating] {
+			d.Rating = r.Rating
+		}
+	}
+	d.RatingScore = ratingScores[d.Rating]
+}
+
+// ratingPolicyFile is the JSON policy file format. Rules are overridden by
+// name, and only the fields given are changed:
+//
+//	{"rules": {"tls_1_2": {"rating": "excellent"}, "no_post_quantum": {"disabled": true}}}
+type ratingPolicyFile struct {
+	Rules map[string]ratingRuleOverride `json:"rules"`
+}
+
+type ratingRuleOverride struct {
+	Severity severity `json:"severity"`
+	Rating   rating   `json:"rating"`
+	Disabled *bool    `json:"disabled"`
+}
+
+func loadRatingPolicy(fp string) (*ratingPolicy, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open rating policy file %#v: %s", fp, err)
+	}
+	defer f.Close()
+	pf := &ratingPolicyFile{}
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	err = dec.Decode(pf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse rating policy file %#v: %s", fp, err)
+	}
+	rp, err := newRatingPolicy(pf)
+	if err != nil {
+		return nil, fmt.Errorf("bad rating policy file %#v: %s", fp, err)
+	}
+	return rp, nil
+}
+
+// newRatingPolicy applies the overrides in pf to the default rules.
+func newRatingPolicy(pf *ratingPolicyFile) (*ratingPolicy, error) {
+	rules := make([]ratingRule, len(defaultRatingRules))
+	copy(rules, defaultRatingRules)
+	index := make(map[string]int, len(rules))
+	for i, r := range rules {
+		index[r.Name] = i
+	}
+	names := make([]string, 0, len(pf.Rules))
+	for name := range pf.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o := pf.Rules[name]
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %#v", name)
+		}
+		if o.Severity != "" {
+			if !severities[o.Severity] {
+				return nil, fmt.Errorf("rule %#v has unknown severity %#v", name, o.Severity)
+			}
+			rules[i].Severity = o.Severity
+		}
+		if o.Rating != "" {
+			if _, ok := ratingOrder[o.Rating]; !ok {
+				return nil, fmt.Errorf("rule %#v has unknown rating %#v; use %#v, %#v or %#v", name, o.Rating, good, improvable, bad)
+			}
+			rules[i].Rating = o.Rating
+		}
+		if o.Disabled != nil {
+			rules[i].Disabled = *o.Disabled
+		}
+	}
+	return &ratingPolicy{rules: rules}, nil
+}
+
+func reloadRatingPolicyForever(policyFile string, rpa *ratingPolicyAtomic, tick *time.Ticker) {
+	for range tick.C {
+		rp, err := loadRatingPolicy(policyFile)
+		if err != nil {
+			log.Printf("unable to reload rating policy at %#v: %s", policyFile, err)
+			continue
+		}
+		rpa.Store(rp)
+	}
+}
+
+type ratingPolicyAtomic atomic.Value
+
+func (a *ratingPolicyAtomic) Load() *ratingPolicy {
+	return (*atomic.Value)(a).Load().(*ratingPolicy)
+}
+
+func (a *ratingPolicyAtomic) Store(rp *ratingPolicy) {
+	(*atomic.Value)(a).Store(rp)
+}
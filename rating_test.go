@@ -0,0 +1,153 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// goodClientInfo returns a clientInfo that fails none of the default rules.
+func goodClientInfo() *clientInfo {
+	return &clientInfo{
+		EphemeralKeysSupported:        true,
+		SessionTicketsSupported:       true,
+		PostQuantumReady:              true,
+		ExtendedMasterSecretSupported: true,
+		SecureRenegotiationSupported:  true,
+		version:                       tls.VersionTLS13,
+	}
+}
+
+func TestDefaultRatingPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(d *clientInfo)
+		want      rating
+		wantRules []string
+	}{
+		{"nothing wrong", func(d *clientInfo) {}, good, []string{}},
+		{"TLS 1.2", func(d *clientInfo) { d.version = tls.VersionTLS12 }, improvable, []string{"tls_1_2"}},
+		{
+			"TLS 1.2 without EMS",
+			func(d *clientInfo) {
+				d.version = tls.VersionTLS12
+				d.ExtendedMasterSecretSupported = false
+			},
+			improvable,
+			[]string{"tls_1_2", "no_extended_master_secret"},
+		},
+		{"TLS 1.3 without EMS", func(d *clientInfo) { d.ExtendedMasterSecretSupported = false }, good, []string{}},
+		{
+			"TLS 1.1",
+			func(d *clientInfo) {
+				d.version = tls.VersionTLS11
+				d.ExtendedMasterSecretSupported = false
+				d.SecureRenegotiationSupported = false
+			},
+			bad,
+			[]string{"no_extended_master_secret", "no_secure_renegotiation", "tls_1_1_or_older"},
+		},
+		{"MD5 signatures", func(d *clientInfo) { d.md5SigSupported = true }, bad, []string{"md5_signature_algorithms"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := goodClientInfo()
+			tt.setup(d)
+			defaultRatingPolicy.rate(d)
+			if d.Rating != tt.want {
+				t.Errorf("rating: want %s, got %s", tt.want, d.Rating)
+			}
+			if d.RatingScore != ratingScores[tt.want] {
+				t.Errorf("rating score: want %d, got %d", ratingScores[tt.want], d.RatingScore)
+			}
+			var got []string
+			for _, r := range d.FailedRatingRules {
+				got = append(got, r.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("failed rules: want %q, got %q", tt.wantRules, got)
+			}
+		})
+	}
+}
+
+func TestLoadRatingPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rating_policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fp := filepath.Join(dir, "policy.json")
+	err = ioutil.WriteFile(fp, []byte(`{"rules": {
+		"tls_1_2": {"rating": "excellent", "severity": "medium"},
+		"no_post_quantum": {"disabled": true},
+		"no_session_tickets": {"rating": "bad"}
+	}}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp, err := loadRatingPolicy(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := goodClientInfo()
+	d.version = tls.VersionTLS12
+	d.PostQuantumReady = false
+	rp.rate(d)
+	if d.Rating != good {
+		t.Errorf("TLS 1.2 without post-quantum groups: want %s, got %s", good, d.Rating)
+	}
+	if len(d.FailedRatingRules) != 1 || d.FailedRatingRules[0].Name != "tls_1_2" || d.FailedRatingRules[0].Severity != severityMedium {
+		t.Errorf("failed rules: want only tls_1_2 with medium severity, got %+v", d.FailedRatingRules)
+	}
+
+	d.SessionTicketsSupported = false
+	rp.rate(d)
+	if d.Rating != bad {
+		t.Errorf("without session tickets: want %s, got %s", bad, d.Rating)
+	}
+
+	// The defaults must be left alone.
+	d = goodClientInfo()
+	d.version = tls.VersionTLS12
+	defaultRatingPolicy.rate(d)
+	if d.Rating != improvable {
+		t.Errorf("default policy after loading a file: want %s, got %s", improvable, d.Rating)
+	}
+}
+
+func TestBadRatingPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		errStr string
+	}{
+		{`{"rules": {"tls_1_0": {"rating": "bad"}}}`, `unknown rule "tls_1_0"`},
+		{`{"rules": {"tls_1_2": {"rating": "terrible"}}}`, `unknown rating "terrible"`},
+		{`{"rules": {"tls_1_2": {"severity": "urgent"}}}`, `unknown severity "urgent"`},
+		{`{"rules": {"tls_1_2": {"effect": "bad"}}}`, `unknown field "effect"`},
+		{`{"rules": [`, `unable to parse`},
+	}
+	dir, err := ioutil.TempDir("", "rating_policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for i, tt := range tests {
+		fp := filepath.Join(dir, "policy.json")
+		if err := ioutil.WriteFile(fp, []byte(tt.policy), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := loadRatingPolicy(fp)
+		if err == nil || !strings.Contains(err.Error(), tt.errStr) {
+			t.Errorf("#%d: want error containing %q, got %v", i, tt.errStr, err)
+		}
+	}
+	if _, err := loadRatingPolicy(filepath.Join(dir, "nope.json")); err == nil {
+		t.Errorf("missing policy file: want error, got nil")
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sullivanmatt/check.tls.support/verify"
+)
+
+// nonceRegexp matches the nonces we accept, which covers hex, base64 and
+// base64url encoded ones.
+var nonceRegexp = regexp.MustCompile(`^[A-Za-z0-9+/=_.~-]{1,256}$`)
+
+// newResultClaims returns the claims binding the API result for r to it:
+// the caller's nonce and audience parameters, the IP address it came from,
+// and when the result is valid. Signing them along with the result keeps it
+// from being replayed later or by someone else.
+func newResultClaims(r *http.Request, now time.Time, ttl time.Duration) (*verify.Claims, error) {
+	nonce := r.FormValue("nonce")
+	if nonce != "" && !nonceRegexp.MatchString(nonce) {
+		return nil, errors.New("nonce must be at most 256 letters, digits or characters from \"+/=_.~-\"")
+	}
+	aud := r.FormValue("audience")
+	nonPrintable := func(c rune) bool { return c < 0x20 || c > 0x7e }
+	if len(aud) > 256 || strings.IndexFunc(aud, nonPrintable) != -1 {
+		return nil, errors.New("audience must be at most 256 printable ASCII characters")
+	}
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return &verify.Claims{
+		Nonce:    nonce,
+		Audience: aud,
+		IssuedAt: now.Unix(),
+		Expires:  now.Add(ttl).Unix(),
+		ClientIP: ip,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewResultClaims(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r := httptest.NewRequest("GET", "/?nonce=abc-123_XYZ&audience=https://example.com/login", nil)
+	r.RemoteAddr = "[2001:db8::1]:54321"
+	c, err := newResultClaims(r, now, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Nonce != "abc-123_XYZ" || c.Audience != "https://example.com/login" || c.ClientIP != "2001:db8::1" {
+		t.Errorf("want the request's nonce, audience and IP, got %+v", c)
+	}
+	if c.IssuedAt != now.Unix() || c.Expires != now.Add(5*time.Minute).Unix() {
+		t.Errorf("want iat %d and exp %d, got %+v", now.Unix(), now.Add(5*time.Minute).Unix(), c)
+	}
+
+	for _, q := range []string{"nonce=a%20b", "nonce=%C3%A9", "audience=a%0Ab"} {
+		r := httptest.NewRequest("GET", "/?"+q, nil)
+		if _, err := newResultClaims(r, now, time.Minute); err == nil {
+			t.Errorf("%s: want an error", q)
+		}
+	}
+}
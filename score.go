@@ -0,0 +1,197 @@
+package main
+
+import (
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// scoreInfo is a 0 to 100 score of the client, in the style of the SSL Labs
+// server rating, along with the category subscores it's made from. Higher is
+// better in every field.
+//
+// Overall is the weighted sum of the subscores, 30% each for protocol
+// support, key exchange and cipher strength and 10% for extension hygiene.
+// It is then capped by the rating, at 80 for "not bad" clients and 40 for
+// "bad" ones, so that a single serious problem can't be averaged away.
+type scoreInfo struct {
+	Overall int `json:"overall"`
+
+	// ProtocolSupport is the average of the scores of the highest and
+	// lowest TLS versions the client offered: 100 for TLS 1.3, 90 for TLS
+	// 1.2, 60 for TLS 1.1, 40 for TLS 1.0 and 0 for SSL 3.0. Clients that
+	// don't send supported_versions only reveal their highest version.
+	ProtocolSupport int `json:"protocol_support"`
+
+	// KeyExchange starts at 90, or 100 for clients offering a post-quantum
+	// group, and is lowered to the score of the weakest group offered: 20
+	// below 80 bits of security, 40 below 112 and 70 below 128. Accepting
+	// Diffie-Hellman parameters smaller than 1024 bits lowers it to 20,
+	// 1024-bit ones to 40, and offering no ephemeral key exchange at all
+	// lowers it to 40.
+	KeyExchange int `json:"key_exchange"`
+
+	// CipherStrength is the average of the scores of the strongest and
+	// weakest cipher suites the client offered: 0 for broken suites, 20 for
+	// 3DES, 60 for other non-AEAD suites, 90 for AEAD suites with 128-bit
+	// keys and 100 for AEAD suites with 256-bit keys. Suites unknown to the
+	// registry are left out.
+	CipherStrength int `json:"cipher_strength"`
+
+	// ExtensionHygiene starts at 100 and loses 50 for offering TLS
+	// compression, 40 for offering MD5 signatures or 20 for other weak
+	// signature algorithms, 20 each for missing extended master secret or
+	// secure renegotiation before TLS 1.3, 10 for missing session tickets
+	// and 10 for sending TLS_FALLBACK_SCSV. It never drops below 0.
+	ExtensionHygiene int `json:"extension_hygiene"`
+}
+
+var (
+	versionScores = map[string]int{
+		"SSL 3.0": 0,
+		"TLS 1.0": 40,
+		"TLS 1.1": 60,
+		"TLS 1.2": 90,
+		"TLS 1.3": 100,
+	}
+
+	// ratingScoreCaps are the highest overall score each rating allows.
+	ratingScoreCaps = map[rating]int{
+		good:       100,
+		improvable: 80,
+		bad:        40,
+	}
+)
+
+// scoreClient scores the client. It must be called after the client has
+// been rated.
+func scoreClient(d *clientInfo, st *tls.ConnectionState) scoreInfo {
+	s := scoreInfo{
+		ProtocolSupport:  protocolSupportScore(st),
+		KeyExchange:      keyExchangeScore(d),
+		CipherStrength:   cipherStrengthScore(st),
+		ExtensionHygiene: extensionHygieneScore(d),
+	}
+	s.Overall = (30*s.ProtocolSupport + 30*s.KeyExchange + 30*s.CipherStrength + 10*s.ExtensionHygiene + 50) / 100
+	if limit, ok := ratingScoreCaps[d.Rating]; ok && s.Overall > limit {
+		s.Overall = limit
+	}
+	return s
+}
+
+func protocolSupportScore(st *tls.ConnectionState) int {
+	versions := st.SupportedVersions
+	if len(versions) == 0 {
+		versions = []uint16{st.ClientVersion}
+	}
+	best, worst := -1, -1
+	for _, v := range versions {
+		score, ok := versionScores[actualSupportedVersions[v]]
+		if !ok {
+			continue
+		}
+		if best == -1 || score > best {
+			best = score
+		}
+		if worst == -1 || score < worst {
+			worst = score
+		}
+	}
+	if best == -1 {
+		return 0
+	}
+	return (best + worst) / 2
+}
+
+func keyExchangeScore(d *clientInfo) int {
+	score := 90
+	if d.PostQuantumReady {
+		score = 100
+	}
+	lower := func(limit int) {
+		if limit < score {
+			score = limit
+		}
+	}
+	for _, g := range d.SupportedGroups {
+		switch {
+		case g.SecurityBits == 0:
+		case g.SecurityBits < 80:
+			lower(20)
+		case g.SecurityBits < 112:
+			lower(40)
+		case g.SecurityBits < 128:
+			lower(70)
+		}
+	}
+	if d.LogjamVuln {
+		lower(20)
+	}
+	if d.WeakDHGroupAccepted || !d.EphemeralKeysSupported {
+		lower(40)
+	}
+	return score
+}
+
+func cipherStrengthScore(st *tls.ConnectionState) int {
+	best, worst := -1, -1
+	for _, id := range st.ClientCipherSuites {
+		cs, ok := cipherSuites[id]
+		if !ok || cs.Signaling {
+			continue
+		}
+		score := cipherSuiteScore(cs)
+		if best == -1 || score > best {
+			best = score
+		}
+		if worst == -1 || score < worst {
+			worst = score
+		}
+	}
+	if best == -1 {
+		return 0
+	}
+	return (best + worst) / 2
+}
+
+func cipherSuiteScore(cs cipherSuite) int {
+	switch {
+	case len(cs.brokenReasons()) != 0:
+		return 0
+	case cs.Cipher == "3DES_EDE":
+		return 20
+	case !cs.AEAD:
+		return 60
+	case cs.KeyBits < 256:
+		return 90
+	}
+	return 100
+}
+
+func extensionHygieneScore(d *clientInfo) int {
+	score := 100
+	if d.TLSCompressionSupported {
+		score -= 50
+	}
+	if d.md5SigSupported {
+		score -= 40
+	} else if len(d.WeakSignatureAlgorithms) != 0 {
+		score -= 20
+	}
+	if d.version != tls.VersionTLS13 {
+		if !d.ExtendedMasterSecretSupported {
+			score -= 20
+		}
+		if !d.SecureRenegotiationSupported {
+			score -= 20
+		}
+	}
+	if !d.SessionTicketsSupported {
+		score -= 10
+	}
+	if d.FallbackSCSVSent {
+		score -= 10
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
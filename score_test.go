@@ -0,0 +1,87 @@
+package main
+
+import (
+	stdtls "crypto/tls"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func TestScoreModernClient(t *testing.T) {
+	c := connectStdlib(t, &stdtls.Config{})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if d.Score.ProtocolSupport != 95 {
+		t.Errorf("ProtocolSupport: want 95 for TLS 1.2 and 1.3, got %d", d.Score.ProtocolSupport)
+	}
+	if d.Score.CipherStrength < 60 {
+		t.Errorf("CipherStrength: want at least 60, got %d", d.Score.CipherStrength)
+	}
+	if d.Score.Overall < 70 || d.Score.Overall > ratingScoreCaps[d.Rating] {
+		t.Errorf("Overall: want between 70 and %d for a %#v client, got %d", ratingScoreCaps[d.Rating], d.Rating, d.Score.Overall)
+	}
+}
+
+func TestScoreCappedByRating(t *testing.T) {
+	c := connect(t, &tls.Config{
+		MaxVersion:   tls.VersionTLS10,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_RSA_WITH_RC4_128_SHA},
+	})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if d.Rating != bad {
+		t.Fatalf("want rating %#v, got %#v", bad, d.Rating)
+	}
+	if d.Score.Overall > 40 {
+		t.Errorf("Overall: want at most 40 for a bad client, got %d", d.Score.Overall)
+	}
+	if d.Score.ProtocolSupport != 40 {
+		t.Errorf("ProtocolSupport: want 40 for TLS 1.0, got %d", d.Score.ProtocolSupport)
+	}
+	if d.Score.CipherStrength != 30 {
+		t.Errorf("CipherStrength: want 30 for CBC and RC4, got %d", d.Score.CipherStrength)
+	}
+}
+
+func TestSubscores(t *testing.T) {
+	st := &tls.ConnectionState{
+		ClientVersion:     tls.VersionTLS12,
+		SupportedVersions: []uint16{0x0a0a, tls.VersionTLS13, tls.VersionTLS12, tls.VersionTLS11},
+		ClientCipherSuites: []uint16{
+			0x0a0a,
+			tls.TLS_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
+			0xfff0,
+		},
+	}
+	if got := protocolSupportScore(st); got != 80 {
+		t.Errorf("protocolSupportScore: want 80, got %d", got)
+	}
+	if got := cipherStrengthScore(st); got != 60 {
+		t.Errorf("cipherStrengthScore: want 60, got %d", got)
+	}
+
+	d := goodClientInfo()
+	if got := keyExchangeScore(d); got != 100 {
+		t.Errorf("keyExchangeScore: want 100, got %d", got)
+	}
+	d.SupportedGroups = namedGroups([]tls.CurveID{tls.X25519, 21})
+	if got := keyExchangeScore(d); got != 70 {
+		t.Errorf("keyExchangeScore with secp224r1: want 70, got %d", got)
+	}
+	d.LogjamVuln = true
+	if got := keyExchangeScore(d); got != 20 {
+		t.Errorf("keyExchangeScore with Logjam: want 20, got %d", got)
+	}
+
+	d = goodClientInfo()
+	if got := extensionHygieneScore(d); got != 100 {
+		t.Errorf("extensionHygieneScore: want 100, got %d", got)
+	}
+	d.version = tls.VersionTLS12
+	d.ExtendedMasterSecretSupported = false
+	d.TLSCompressionSupported = true
+	d.md5SigSupported = true
+	if got := extensionHygieneScore(d); got != 0 {
+		t.Errorf("extensionHygieneScore: want 0, got %d", got)
+	}
+}
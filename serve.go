@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+type contextKey string
+
+// connContextKey is the request context key of the *conn the request came
+// in on, so that handlers can inspect its handshake.
+const connContextKey contextKey = "conn"
+
+var errConnQueueClosed = errors.New("connQueue: listener closed")
+
+// connContext stores c in ctx. It's the https server's ConnContext.
+func connContext(ctx context.Context, c net.Conn) context.Context {
+	if tc, ok := c.(*conn); ok {
+		return context.WithValue(ctx, connContextKey, tc)
+	}
+	return ctx
+}
+
+// connFromRequest returns the *conn the request came in on.
+func connFromRequest(r *http.Request) (*conn, bool) {
+	tc, ok := r.Context().Value(connContextKey).(*conn)
+	return tc, ok
+}
+
+// serveTLS serves HTTPS on l, which must be a *listener. net/http only
+// speaks HTTP/2 over crypto/tls connections, so the connections that
+// negotiate h2 with ALPN are served by h2s here and the rest are handed to
+// srv. h2s must have been set up with http2.ConfigureServer(srv, h2s) so that
+// srv.Shutdown also shuts down the HTTP/2 connections.
+func serveTLS(srv *http.Server, h2s *http2.Server, l net.Listener) error {
+	q := &connQueue{
+		addr:  l.Addr(),
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+	srv.RegisterOnShutdown(func() { l.Close() })
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(q)
+	}()
+	var tempDelay time.Duration
+	for {
+		c, err := l.Accept()
+		if ne, ok := err.(net.Error); ok && ne.Temporary() {
+			// Back off like net/http does, rather than giving up on
+			// something like running out of file descriptors.
+			if tempDelay == 0 {
+				tempDelay = 5 * time.Millisecond
+			} else if tempDelay *= 2; tempDelay > time.Second {
+				tempDelay = time.Second
+			}
+			log.Printf("https accept error: %s; retrying in %s", err, tempDelay)
+			time.Sleep(tempDelay)
+			continue
+		}
+		tempDelay = 0
+		if err != nil {
+			q.Close()
+			if srvErr := <-errCh; srvErr == http.ErrServerClosed {
+				return srvErr
+			}
+			return err
+		}
+		go dispatchConn(srv, h2s, q, c.(*conn))
+	}
+}
+
+// dispatchConn finishes the handshake of c and serves it as HTTP/2 if that's
+// what was negotiated, and queues it for srv otherwise.
+func dispatchConn(srv *http.Server, h2s *http2.Server, q *connQueue, c *conn) {
+	if srv.ReadTimeout != 0 {
+		c.SetReadDeadline(time.Now().Add(srv.ReadTimeout))
+	}
+	err := c.handshake()
+	if err != nil {
+		c.errorToStats(err)
+		c.Close()
+		return
+	}
+	c.SetReadDeadline(time.Time{})
+
+	if c.ConnectionState().NegotiatedProtocol != http2.NextProtoTLS {
+		if !q.push(c) {
+			c.Close()
+		}
+		return
+	}
+	incrementHTTP2Conns()
+	defer decrementHTTP2Conns()
+	h2s.ServeConn(c, &http2.ServeConnOpts{
+		Context:    connContext(context.Background(), c),
+		BaseConfig: srv,
+		Handler:    srv.Handler,
+	})
+}
+
+// connQueue is a net.Listener that returns the connections pushed to it.
+type connQueue struct {
+	addr  net.Addr
+	conns chan net.Conn
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func (q *connQueue) push(c net.Conn) bool {
+	select {
+	case q.conns <- c:
+		return true
+	case <-q.done:
+		return false
+	}
+}
+
+func (q *connQueue) Accept() (net.Conn, error) {
+	select {
+	case c := <-q.conns:
+		return c, nil
+	case <-q.done:
+		return nil, errConnQueueClosed
+	}
+}
+
+func (q *connQueue) Close() error {
+	q.closeOnce.Do(func() { close(q.done) })
+	return nil
+}
+
+func (q *connQueue) Addr() net.Addr {
+	return q.addr
+}
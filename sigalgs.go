@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+var (
+	sha1SigReason = "The signature algorithm uses SHA-1, which is vulnerable to practical collision attacks."
+	md5SigReason  = "The signature algorithm uses the broken MD5 hash function."
+	dsaSigReason  = "The signature algorithm uses DSA, which was removed from TLS 1.3 and is rarely implemented safely."
+)
+
+// allSignatureSchemes maps the signature_algorithms code points to their
+// names in the IANA TLS SignatureScheme registry. The TLS 1.2 hash and
+// signature pairs that were never given a SignatureScheme name use the
+// "<signature>_<hash>" form OpenSSL uses.
+// https://www.iana.org/assignments/tls-parameters/tls-parameters.xhtml#tls-signaturescheme
+var allSignatureSchemes = map[tls.SignatureScheme]string{
+	0x0101: "rsa_pkcs1_md5",
+	0x0102: "dsa_md5",
+	0x0103: "ecdsa_md5",
+	0x0201: "rsa_pkcs1_sha1",
+	0x0202: "dsa_sha1",
+	0x0203: "ecdsa_sha1",
+	0x0301: "rsa_pkcs1_sha224",
+	0x0302: "dsa_sha224",
+	0x0303: "ecdsa_sha224",
+	0x0401: "rsa_pkcs1_sha256",
+	0x0402: "dsa_sha256",
+	0x0403: "ecdsa_secp256r1_sha256",
+	0x0420: "rsa_pkcs1_sha256_legacy",
+	0x0501: "rsa_pkcs1_sha384",
+	0x0502: "dsa_sha384",
+	0x0503: "ecdsa_secp384r1_sha384",
+	0x0520: "rsa_pkcs1_sha384_legacy",
+	0x0601: "rsa_pkcs1_sha512",
+	0x0602: "dsa_sha512",
+	0x0603: "ecdsa_secp521r1_sha512",
+	0x0620: "rsa_pkcs1_sha512_legacy",
+	0x0704: "eccsi_sha256",
+	0x0705: "iso_ibs1",
+	0x0706: "iso_ibs2",
+	0x0707: "iso_chinese_ibs",
+	0x0708: "sm2sig_sm3",
+	0x0709: "gostr34102012_256a",
+	0x070a: "gostr34102012_256b",
+	0x070b: "gostr34102012_256c",
+	0x070c: "gostr34102012_256d",
+	0x070d: "gostr34102012_512a",
+	0x070e: "gostr34102012_512b",
+	0x070f: "gostr34102012_512c",
+	0x0804: "rsa_pss_rsae_sha256",
+	0x0805: "rsa_pss_rsae_sha384",
+	0x0806: "rsa_pss_rsae_sha512",
+	0x0807: "ed25519",
+	0x0808: "ed448",
+	0x0809: "rsa_pss_pss_sha256",
+	0x080a: "rsa_pss_pss_sha384",
+	0x080b: "rsa_pss_pss_sha512",
+	0x081a: "ecdsa_brainpoolP256r1tls13_sha256",
+	0x081b: "ecdsa_brainpoolP384r1tls13_sha384",
+	0x081c: "ecdsa_brainpoolP512r1tls13_sha512",
+	0x0904: "mldsa44",
+	0x0905: "mldsa65",
+	0x0906: "mldsa87",
+}
+
+// signatureSchemeName returns the registry name of the signature scheme.
+func signatureSchemeName(s tls.SignatureScheme) string {
+	if n, ok := allSignatureSchemes[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("An unknown signature algorithm: %#04x", uint16(s))
+}
+
+// signatureSchemeNames names each non-GREASE scheme in ss, keeping their
+// order.
+func signatureSchemeNames(ss []tls.SignatureScheme) []string {
+	names := []string{}
+	for _, s := range ss {
+		if !isGREASE(uint16(s)) {
+			names = append(names, signatureSchemeName(s))
+		}
+	}
+	return names
+}
+
+// weakSignatureReasons returns why the signature scheme is weak, or nothing
+// if it isn't. In the TLS 1.2 encoding the low byte is the signature
+// algorithm and the high byte the hash, which covers the unnamed pairs too.
+func weakSignatureReasons(s tls.SignatureScheme) []string {
+	var reasons []string
+	hash, sig := uint8(s>>8), uint8(s)
+	if sig > 3 {
+		// Not a TLS 1.2 hash and signature pair.
+		return nil
+	}
+	switch hash {
+	case 1:
+		reasons = append(reasons, md5SigReason)
+	case 2:
+		reasons = append(reasons, sha1SigReason)
+	}
+	if sig == 2 {
+		reasons = append(reasons, dsaSigReason)
+	}
+	return reasons
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func TestWeakSignatureReasons(t *testing.T) {
+	tests := []struct {
+		scheme tls.SignatureScheme
+		want   []string
+	}{
+		{0x0101, []string{md5SigReason}},
+		{0x0102, []string{md5SigReason, dsaSigReason}},
+		{tls.PKCS1WithSHA1, []string{sha1SigReason}},
+		{tls.ECDSAWithSHA1, []string{sha1SigReason}},
+		{0x0202, []string{sha1SigReason, dsaSigReason}},
+		{0x0402, []string{dsaSigReason}},
+		{tls.PKCS1WithSHA256, nil},
+		{tls.ECDSAWithP256AndSHA256, nil},
+		{tls.PSSWithSHA256, nil},
+		{0x0807, nil},
+		{0x0420, nil},
+		{0x0a0a, nil},
+	}
+	for _, tt := range tests {
+		if got := weakSignatureReasons(tt.scheme); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("weakSignatureReasons(%s): want %v, got %v", signatureSchemeName(tt.scheme), tt.want, got)
+		}
+	}
+}
+
+func TestSignatureSchemeNames(t *testing.T) {
+	got := signatureSchemeNames([]tls.SignatureScheme{0x0a0a, tls.PSSWithSHA256, tls.ECDSAWithP256AndSHA256, tls.PKCS1WithSHA1, 0xfefe})
+	want := []string{"rsa_pss_rsae_sha256", "ecdsa_secp256r1_sha256", "rsa_pkcs1_sha1", "An unknown signature algorithm: 0xfefe"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("signatureSchemeNames: want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+//go:generate go run ./gensuites -o suites_gen.go gensuites/tls-parameters-4.csv
+
+var (
+	fewBitReason      = "The cipher uses broken encryption algorithms."
+	nullReason        = "The cipher specifies that no encryption should be used on the connection, therefore the cipher provides no data confidentiality."
+	nullAuthReason    = "The cipher specifies that no authentication should be used on the connection, therefore the cipher provides no data integrity guarantees."
+	rc4Reason         = "The cipher uses the broken RC4 encryption algorithm."
+	sweet32Reason     = "The cipher uses the broken 3DES encryption algorithm in a way that makes it highly vulnerable to the Sweet32 attack."
+	cbcReason         = "The cipher uses cipher block chaining (CBC) mode, which is often implemented improperly, leading to padding oracle attacks."
+	noEphemeralReason = "The cipher does not support ephemeral keys. Use of ephemeral keys greatly improves data confidentiality by generating keys that only last for the duration of the connection."
+
+	fallbackSCSVReason = "The client sent TLS_FALLBACK_SCSV, meaning it retried this connection with an older TLS version after a failed handshake. An attacker who can break handshakes can use this insecure version fallback to downgrade the connection."
+)
+
+// cipherSuite is an entry in the cipher suite registry, cipherSuites, which
+// is generated from the IANA registry by gensuites. The algorithm names are
+// the ones used in the suite names.
+type cipherSuite struct {
+	ID          uint16
+	Name        string
+	KeyExchange string // "RSA", "DHE", "ECDHE_PSK", etc.; empty for TLS 1.3 suites, which negotiate it separately
+	Auth        string // "RSA", "ECDSA", "anon", etc.; empty for TLS 1.3 suites
+	Cipher      string // "AES", "CHACHA20", "3DES_EDE", "RC4", "NULL", etc.
+	Mode        string // "CBC", "GCM", "CCM", "CCM_8", "POLY1305", "stream", etc.
+	KeyBits     int
+	MAC         string // the HMAC hash, or the PRF hash for AEAD suites
+	AEAD        bool
+	MinVersion  uint16
+	Export      bool // export grade, with a weakened key exchange
+	Signaling   bool // an SCSV or GREASE value rather than a real cipher suite
+	Recommended bool // marked Recommended by IANA
+}
+
+// forwardSecret reports whether the suite's key exchange uses ephemeral
+// keys. TLS 1.3 only has ephemeral key exchanges, PSK-only resumption aside.
+func (cs cipherSuite) forwardSecret() bool {
+	if cs.Signaling {
+		return false
+	}
+	if cs.MinVersion >= tls.VersionTLS13 {
+		return true
+	}
+	switch cs.KeyExchange {
+	case "DHE", "ECDHE", "DHE_PSK", "ECDHE_PSK", "CECPQ1":
+		return true
+	}
+	return false
+}
+
+// weakReasons returns why the suite is weak, but not broken.
+func (cs cipherSuite) weakReasons() []string {
+	var reasons []string
+	if cs.Mode == "CBC" {
+		reasons = append(reasons, cbcReason)
+	}
+	if cs.KeyExchange == "RSA" {
+		reasons = append(reasons, noEphemeralReason)
+	}
+	return reasons
+}
+
+// brokenReasons returns why the suite is broken. Sweet32 depends on the
+// suites offered after 3DES ones, so it's left to the caller.
+func (cs cipherSuite) brokenReasons() []string {
+	var reasons []string
+	if cs.Signaling {
+		return nil
+	}
+	if cs.Cipher != "NULL" && cs.KeyBits < 128 {
+		reasons = append(reasons, fewBitReason)
+	}
+	if cs.Cipher == "NULL" {
+		reasons = append(reasons, nullReason)
+	}
+	if cs.Auth == "anon" {
+		reasons = append(reasons, nullAuthReason)
+	}
+	if cs.Cipher == "RC4" {
+		reasons = append(reasons, rc4Reason)
+	}
+	return reasons
+}
+
+// cipherSuiteName returns the registry name of the cipher suite.
+func cipherSuiteName(id uint16) string {
+	if cs, ok := cipherSuites[id]; ok {
+		return cs.Name
+	}
+	return fmt.Sprintf("An unknown cipher suite: %#04x", id)
+}
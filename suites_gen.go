@@ -0,0 +1,392 @@
+// Code generated by gensuites from gensuites/tls-parameters-4.csv. DO NOT EDIT.
+
+package main
+
+import tls "github.com/sullivanmatt/check.tls.support/tls110"
+
+var cipherSuites = map[uint16]cipherSuite{
+	0x0000: {ID: 0x0000, Name: "TLS_NULL_WITH_NULL_NULL", KeyExchange: "NULL", Auth: "NULL", Cipher: "NULL", MAC: "NULL", MinVersion: tls.VersionSSL30},
+	0x0001: {ID: 0x0001, Name: "TLS_RSA_WITH_NULL_MD5", KeyExchange: "RSA", Auth: "RSA", Cipher: "NULL", MAC: "MD5", MinVersion: tls.VersionSSL30},
+	0x0002: {ID: 0x0002, Name: "TLS_RSA_WITH_NULL_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0003: {ID: 0x0003, Name: "TLS_RSA_EXPORT_WITH_RC4_40_MD5", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 40, MAC: "MD5", MinVersion: tls.VersionSSL30, Export: true},
+	0x0004: {ID: 0x0004, Name: "TLS_RSA_WITH_RC4_128_MD5", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "MD5", MinVersion: tls.VersionSSL30},
+	0x0005: {ID: 0x0005, Name: "TLS_RSA_WITH_RC4_128_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0006: {ID: 0x0006, Name: "TLS_RSA_EXPORT_WITH_RC2_CBC_40_MD5", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC2", Mode: "CBC", KeyBits: 40, MAC: "MD5", MinVersion: tls.VersionSSL30, Export: true},
+	0x0007: {ID: 0x0007, Name: "TLS_RSA_WITH_IDEA_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "IDEA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0008: {ID: 0x0008, Name: "TLS_RSA_EXPORT_WITH_DES40_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionSSL30, Export: true},
+	0x0009: {ID: 0x0009, Name: "TLS_RSA_WITH_DES_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x000A: {ID: 0x000A, Name: "TLS_RSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x000B: {ID: 0x000B, Name: "TLS_DH_DSS_EXPORT_WITH_DES40_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionSSL30, Export: true},
+	0x000C: {ID: 0x000C, Name: "TLS_DH_DSS_WITH_DES_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x000D: {ID: 0x000D, Name: "TLS_DH_DSS_WITH_3DES_EDE_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x000E: {ID: 0x000E, Name: "TLS_DH_RSA_EXPORT_WITH_DES40_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionSSL30, Export: true},
+	0x000F: {ID: 0x000F, Name: "TLS_DH_RSA_WITH_DES_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0010: {ID: 0x0010, Name: "TLS_DH_RSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0011: {ID: 0x0011, Name: "TLS_DHE_DSS_EXPORT_WITH_DES40_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionSSL30, Export: true},
+	0x0012: {ID: 0x0012, Name: "TLS_DHE_DSS_WITH_DES_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0013: {ID: 0x0013, Name: "TLS_DHE_DSS_WITH_3DES_EDE_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0014: {ID: 0x0014, Name: "TLS_DHE_RSA_EXPORT_WITH_DES40_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionSSL30, Export: true},
+	0x0015: {ID: 0x0015, Name: "TLS_DHE_RSA_WITH_DES_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0016: {ID: 0x0016, Name: "TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x0017: {ID: 0x0017, Name: "TLS_DH_anon_EXPORT_WITH_RC4_40_MD5", KeyExchange: "DH", Auth: "anon", Cipher: "RC4", Mode: "stream", KeyBits: 40, MAC: "MD5", MinVersion: tls.VersionSSL30, Export: true},
+	0x0018: {ID: 0x0018, Name: "TLS_DH_anon_WITH_RC4_128_MD5", KeyExchange: "DH", Auth: "anon", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "MD5", MinVersion: tls.VersionSSL30},
+	0x0019: {ID: 0x0019, Name: "TLS_DH_anon_EXPORT_WITH_DES40_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionSSL30, Export: true},
+	0x001A: {ID: 0x001A, Name: "TLS_DH_anon_WITH_DES_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x001B: {ID: 0x001B, Name: "TLS_DH_anon_WITH_3DES_EDE_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionSSL30},
+	0x001E: {ID: 0x001E, Name: "TLS_KRB5_WITH_DES_CBC_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x001F: {ID: 0x001F, Name: "TLS_KRB5_WITH_3DES_EDE_CBC_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0020: {ID: 0x0020, Name: "TLS_KRB5_WITH_RC4_128_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0021: {ID: 0x0021, Name: "TLS_KRB5_WITH_IDEA_CBC_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "IDEA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0022: {ID: 0x0022, Name: "TLS_KRB5_WITH_DES_CBC_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "MD5", MinVersion: tls.VersionTLS10},
+	0x0023: {ID: 0x0023, Name: "TLS_KRB5_WITH_3DES_EDE_CBC_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "MD5", MinVersion: tls.VersionTLS10},
+	0x0024: {ID: 0x0024, Name: "TLS_KRB5_WITH_RC4_128_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "MD5", MinVersion: tls.VersionTLS10},
+	0x0025: {ID: 0x0025, Name: "TLS_KRB5_WITH_IDEA_CBC_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "IDEA", Mode: "CBC", KeyBits: 128, MAC: "MD5", MinVersion: tls.VersionTLS10},
+	0x0026: {ID: 0x0026, Name: "TLS_KRB5_EXPORT_WITH_DES_CBC_40_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0027: {ID: 0x0027, Name: "TLS_KRB5_EXPORT_WITH_RC2_CBC_40_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "RC2", Mode: "CBC", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0028: {ID: 0x0028, Name: "TLS_KRB5_EXPORT_WITH_RC4_40_SHA", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "RC4", Mode: "stream", KeyBits: 40, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0029: {ID: 0x0029, Name: "TLS_KRB5_EXPORT_WITH_DES_CBC_40_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "DES", Mode: "CBC", KeyBits: 40, MAC: "MD5", MinVersion: tls.VersionTLS10, Export: true},
+	0x002A: {ID: 0x002A, Name: "TLS_KRB5_EXPORT_WITH_RC2_CBC_40_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "RC2", Mode: "CBC", KeyBits: 40, MAC: "MD5", MinVersion: tls.VersionTLS10, Export: true},
+	0x002B: {ID: 0x002B, Name: "TLS_KRB5_EXPORT_WITH_RC4_40_MD5", KeyExchange: "KRB5", Auth: "KRB5", Cipher: "RC4", Mode: "stream", KeyBits: 40, MAC: "MD5", MinVersion: tls.VersionTLS10, Export: true},
+	0x002C: {ID: 0x002C, Name: "TLS_PSK_WITH_NULL_SHA", KeyExchange: "PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x002D: {ID: 0x002D, Name: "TLS_DHE_PSK_WITH_NULL_SHA", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x002E: {ID: 0x002E, Name: "TLS_RSA_PSK_WITH_NULL_SHA", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x002F: {ID: 0x002F, Name: "TLS_RSA_WITH_AES_128_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0030: {ID: 0x0030, Name: "TLS_DH_DSS_WITH_AES_128_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0031: {ID: 0x0031, Name: "TLS_DH_RSA_WITH_AES_128_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0032: {ID: 0x0032, Name: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0033: {ID: 0x0033, Name: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0034: {ID: 0x0034, Name: "TLS_DH_anon_WITH_AES_128_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0035: {ID: 0x0035, Name: "TLS_RSA_WITH_AES_256_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0036: {ID: 0x0036, Name: "TLS_DH_DSS_WITH_AES_256_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0037: {ID: 0x0037, Name: "TLS_DH_RSA_WITH_AES_256_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0038: {ID: 0x0038, Name: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0039: {ID: 0x0039, Name: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x003A: {ID: 0x003A, Name: "TLS_DH_anon_WITH_AES_256_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x003B: {ID: 0x003B, Name: "TLS_RSA_WITH_NULL_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "NULL", MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x003C: {ID: 0x003C, Name: "TLS_RSA_WITH_AES_128_CBC_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x003D: {ID: 0x003D, Name: "TLS_RSA_WITH_AES_256_CBC_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x003E: {ID: 0x003E, Name: "TLS_DH_DSS_WITH_AES_128_CBC_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x003F: {ID: 0x003F, Name: "TLS_DH_RSA_WITH_AES_128_CBC_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x0040: {ID: 0x0040, Name: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x0041: {ID: 0x0041, Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0042: {ID: 0x0042, Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0043: {ID: 0x0043, Name: "TLS_DH_RSA_WITH_CAMELLIA_128_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0044: {ID: 0x0044, Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0045: {ID: 0x0045, Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0046: {ID: 0x0046, Name: "TLS_DH_anon_WITH_CAMELLIA_128_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0060: {ID: 0x0060, Name: "TLS_RSA_EXPORT1024_WITH_RC4_56_MD5", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 56, MAC: "MD5", MinVersion: tls.VersionTLS10, Export: true},
+	0x0061: {ID: 0x0061, Name: "TLS_RSA_EXPORT1024_WITH_RC2_CBC_56_MD5", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC2", Mode: "CBC", KeyBits: 56, MAC: "MD5", MinVersion: tls.VersionTLS10, Export: true},
+	0x0062: {ID: 0x0062, Name: "TLS_RSA_EXPORT1024_WITH_DES_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0063: {ID: 0x0063, Name: "TLS_DHE_DSS_EXPORT1024_WITH_DES_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0064: {ID: 0x0064, Name: "TLS_RSA_EXPORT1024_WITH_RC4_56_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0065: {ID: 0x0065, Name: "TLS_DHE_DSS_EXPORT1024_WITH_RC4_56_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "RC4", Mode: "stream", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionTLS10, Export: true},
+	0x0066: {ID: 0x0066, Name: "TLS_DHE_DSS_WITH_RC4_128_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0067: {ID: 0x0067, Name: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x0068: {ID: 0x0068, Name: "TLS_DH_DSS_WITH_AES_256_CBC_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x0069: {ID: 0x0069, Name: "TLS_DH_RSA_WITH_AES_256_CBC_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x006A: {ID: 0x006A, Name: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x006B: {ID: 0x006B, Name: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x006C: {ID: 0x006C, Name: "TLS_DH_anon_WITH_AES_128_CBC_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x006D: {ID: 0x006D, Name: "TLS_DH_anon_WITH_AES_256_CBC_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x0081: {ID: 0x0081, Name: "TLS_GOST2001-GOST89-GOST89", KeyExchange: "GOSTR341001", Auth: "GOSTR341001", Cipher: "28147", Mode: "CNT", KeyBits: 256, MAC: "IMIT", MinVersion: tls.VersionTLS12},
+	0x0084: {ID: 0x0084, Name: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0085: {ID: 0x0085, Name: "TLS_DH_DSS_WITH_CAMELLIA_256_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0086: {ID: 0x0086, Name: "TLS_DH_RSA_WITH_CAMELLIA_256_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0087: {ID: 0x0087, Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0088: {ID: 0x0088, Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0089: {ID: 0x0089, Name: "TLS_DH_anon_WITH_CAMELLIA_256_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x008A: {ID: 0x008A, Name: "TLS_PSK_WITH_RC4_128_SHA", KeyExchange: "PSK", Auth: "PSK", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x008B: {ID: 0x008B, Name: "TLS_PSK_WITH_3DES_EDE_CBC_SHA", KeyExchange: "PSK", Auth: "PSK", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x008C: {ID: 0x008C, Name: "TLS_PSK_WITH_AES_128_CBC_SHA", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x008D: {ID: 0x008D, Name: "TLS_PSK_WITH_AES_256_CBC_SHA", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x008E: {ID: 0x008E, Name: "TLS_DHE_PSK_WITH_RC4_128_SHA", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x008F: {ID: 0x008F, Name: "TLS_DHE_PSK_WITH_3DES_EDE_CBC_SHA", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0090: {ID: 0x0090, Name: "TLS_DHE_PSK_WITH_AES_128_CBC_SHA", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0091: {ID: 0x0091, Name: "TLS_DHE_PSK_WITH_AES_256_CBC_SHA", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0092: {ID: 0x0092, Name: "TLS_RSA_PSK_WITH_RC4_128_SHA", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0093: {ID: 0x0093, Name: "TLS_RSA_PSK_WITH_3DES_EDE_CBC_SHA", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0094: {ID: 0x0094, Name: "TLS_RSA_PSK_WITH_AES_128_CBC_SHA", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0095: {ID: 0x0095, Name: "TLS_RSA_PSK_WITH_AES_256_CBC_SHA", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0096: {ID: 0x0096, Name: "TLS_RSA_WITH_SEED_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "SEED", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0097: {ID: 0x0097, Name: "TLS_DH_DSS_WITH_SEED_CBC_SHA", KeyExchange: "DH", Auth: "DSS", Cipher: "SEED", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0098: {ID: 0x0098, Name: "TLS_DH_RSA_WITH_SEED_CBC_SHA", KeyExchange: "DH", Auth: "RSA", Cipher: "SEED", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x0099: {ID: 0x0099, Name: "TLS_DHE_DSS_WITH_SEED_CBC_SHA", KeyExchange: "DHE", Auth: "DSS", Cipher: "SEED", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x009A: {ID: 0x009A, Name: "TLS_DHE_RSA_WITH_SEED_CBC_SHA", KeyExchange: "DHE", Auth: "RSA", Cipher: "SEED", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x009B: {ID: 0x009B, Name: "TLS_DH_anon_WITH_SEED_CBC_SHA", KeyExchange: "DH", Auth: "anon", Cipher: "SEED", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0x009C: {ID: 0x009C, Name: "TLS_RSA_WITH_AES_128_GCM_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x009D: {ID: 0x009D, Name: "TLS_RSA_WITH_AES_256_GCM_SHA384", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x009E: {ID: 0x009E, Name: "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0x009F: {ID: 0x009F, Name: "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0x00A0: {ID: 0x00A0, Name: "TLS_DH_RSA_WITH_AES_128_GCM_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A1: {ID: 0x00A1, Name: "TLS_DH_RSA_WITH_AES_256_GCM_SHA384", KeyExchange: "DH", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A2: {ID: 0x00A2, Name: "TLS_DHE_DSS_WITH_AES_128_GCM_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A3: {ID: 0x00A3, Name: "TLS_DHE_DSS_WITH_AES_256_GCM_SHA384", KeyExchange: "DHE", Auth: "DSS", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A4: {ID: 0x00A4, Name: "TLS_DH_DSS_WITH_AES_128_GCM_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A5: {ID: 0x00A5, Name: "TLS_DH_DSS_WITH_AES_256_GCM_SHA384", KeyExchange: "DH", Auth: "DSS", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A6: {ID: 0x00A6, Name: "TLS_DH_anon_WITH_AES_128_GCM_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A7: {ID: 0x00A7, Name: "TLS_DH_anon_WITH_AES_256_GCM_SHA384", KeyExchange: "DH", Auth: "anon", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A8: {ID: 0x00A8, Name: "TLS_PSK_WITH_AES_128_GCM_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00A9: {ID: 0x00A9, Name: "TLS_PSK_WITH_AES_256_GCM_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00AA: {ID: 0x00AA, Name: "TLS_DHE_PSK_WITH_AES_128_GCM_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0x00AB: {ID: 0x00AB, Name: "TLS_DHE_PSK_WITH_AES_256_GCM_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0x00AC: {ID: 0x00AC, Name: "TLS_RSA_PSK_WITH_AES_128_GCM_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00AD: {ID: 0x00AD, Name: "TLS_RSA_PSK_WITH_AES_256_GCM_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x00AE: {ID: 0x00AE, Name: "TLS_PSK_WITH_AES_128_CBC_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00AF: {ID: 0x00AF, Name: "TLS_PSK_WITH_AES_256_CBC_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0x00B0: {ID: 0x00B0, Name: "TLS_PSK_WITH_NULL_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00B1: {ID: 0x00B1, Name: "TLS_PSK_WITH_NULL_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0x00B2: {ID: 0x00B2, Name: "TLS_DHE_PSK_WITH_AES_128_CBC_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00B3: {ID: 0x00B3, Name: "TLS_DHE_PSK_WITH_AES_256_CBC_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0x00B4: {ID: 0x00B4, Name: "TLS_DHE_PSK_WITH_NULL_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00B5: {ID: 0x00B5, Name: "TLS_DHE_PSK_WITH_NULL_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0x00B6: {ID: 0x00B6, Name: "TLS_RSA_PSK_WITH_AES_128_CBC_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00B7: {ID: 0x00B7, Name: "TLS_RSA_PSK_WITH_AES_256_CBC_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0x00B8: {ID: 0x00B8, Name: "TLS_RSA_PSK_WITH_NULL_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "NULL", MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00B9: {ID: 0x00B9, Name: "TLS_RSA_PSK_WITH_NULL_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "NULL", MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0x00BA: {ID: 0x00BA, Name: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00BB: {ID: 0x00BB, Name: "TLS_DH_DSS_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00BC: {ID: 0x00BC, Name: "TLS_DH_RSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00BD: {ID: 0x00BD, Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00BE: {ID: 0x00BE, Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00BF: {ID: 0x00BF, Name: "TLS_DH_anon_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C0: {ID: 0x00C0, Name: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C1: {ID: 0x00C1, Name: "TLS_DH_DSS_WITH_CAMELLIA_256_CBC_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C2: {ID: 0x00C2, Name: "TLS_DH_RSA_WITH_CAMELLIA_256_CBC_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C3: {ID: 0x00C3, Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C4: {ID: 0x00C4, Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C5: {ID: 0x00C5, Name: "TLS_DH_anon_WITH_CAMELLIA_256_CBC_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0x00C6: {ID: 0x00C6, Name: "TLS_SM4_GCM_SM3", Cipher: "SM4", Mode: "GCM", KeyBits: 128, MAC: "SM3", AEAD: true, MinVersion: tls.VersionTLS13},
+	0x00C7: {ID: 0x00C7, Name: "TLS_SM4_CCM_SM3", Cipher: "SM4", Mode: "CCM", KeyBits: 128, MAC: "SM3", AEAD: true, MinVersion: tls.VersionTLS13},
+	0x00FF: {ID: 0x00FF, Name: "TLS_EMPTY_RENEGOTIATION_INFO_SCSV", Signaling: true, Recommended: true},
+	0x0A0A: {ID: 0x0A0A, Name: "TLS_GREASE_0A", Signaling: true},
+	0x1301: {ID: 0x1301, Name: "TLS_AES_128_GCM_SHA256", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS13, Recommended: true},
+	0x1302: {ID: 0x1302, Name: "TLS_AES_256_GCM_SHA384", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS13, Recommended: true},
+	0x1303: {ID: 0x1303, Name: "TLS_CHACHA20_POLY1305_SHA256", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS13, Recommended: true},
+	0x1304: {ID: 0x1304, Name: "TLS_AES_128_CCM_SHA256", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS13, Recommended: true},
+	0x1305: {ID: 0x1305, Name: "TLS_AES_128_CCM_8_SHA256", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS13},
+	0x16B7: {ID: 0x16B7, Name: "TLS_CECPQ1_RSA_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "CECPQ1", Auth: "RSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x16B8: {ID: 0x16B8, Name: "TLS_CECPQ1_ECDSA_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "CECPQ1", Auth: "ECDSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x16B9: {ID: 0x16B9, Name: "TLS_CECPQ1_RSA_WITH_AES_256_GCM_SHA384", KeyExchange: "CECPQ1", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x16BA: {ID: 0x16BA, Name: "TLS_CECPQ1_ECDSA_WITH_AES_256_GCM_SHA384", KeyExchange: "CECPQ1", Auth: "ECDSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0x1A1A: {ID: 0x1A1A, Name: "TLS_GREASE_1A", Signaling: true},
+	0x2A2A: {ID: 0x2A2A, Name: "TLS_GREASE_2A", Signaling: true},
+	0x3A3A: {ID: 0x3A3A, Name: "TLS_GREASE_3A", Signaling: true},
+	0x4A4A: {ID: 0x4A4A, Name: "TLS_GREASE_4A", Signaling: true},
+	0x5600: {ID: 0x5600, Name: "TLS_FALLBACK_SCSV", Signaling: true, Recommended: true},
+	0x5A5A: {ID: 0x5A5A, Name: "TLS_GREASE_5A", Signaling: true},
+	0x6A6A: {ID: 0x6A6A, Name: "TLS_GREASE_6A", Signaling: true},
+	0x7A7A: {ID: 0x7A7A, Name: "TLS_GREASE_7A", Signaling: true},
+	0x8A8A: {ID: 0x8A8A, Name: "TLS_GREASE_8A", Signaling: true},
+	0x9A9A: {ID: 0x9A9A, Name: "TLS_GREASE_9A", Signaling: true},
+	0xAAAA: {ID: 0xAAAA, Name: "TLS_GREASE_AA", Signaling: true},
+	0xBABA: {ID: 0xBABA, Name: "TLS_GREASE_BA", Signaling: true},
+	0xC001: {ID: 0xC001, Name: "TLS_ECDH_ECDSA_WITH_NULL_SHA", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC002: {ID: 0xC002, Name: "TLS_ECDH_ECDSA_WITH_RC4_128_SHA", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC003: {ID: 0xC003, Name: "TLS_ECDH_ECDSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC004: {ID: 0xC004, Name: "TLS_ECDH_ECDSA_WITH_AES_128_CBC_SHA", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC005: {ID: 0xC005, Name: "TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC006: {ID: 0xC006, Name: "TLS_ECDHE_ECDSA_WITH_NULL_SHA", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC007: {ID: 0xC007, Name: "TLS_ECDHE_ECDSA_WITH_RC4_128_SHA", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC008: {ID: 0xC008, Name: "TLS_ECDHE_ECDSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC009: {ID: 0xC009, Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC00A: {ID: 0xC00A, Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC00B: {ID: 0xC00B, Name: "TLS_ECDH_RSA_WITH_NULL_SHA", KeyExchange: "ECDH", Auth: "RSA", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC00C: {ID: 0xC00C, Name: "TLS_ECDH_RSA_WITH_RC4_128_SHA", KeyExchange: "ECDH", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC00D: {ID: 0xC00D, Name: "TLS_ECDH_RSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "ECDH", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC00E: {ID: 0xC00E, Name: "TLS_ECDH_RSA_WITH_AES_128_CBC_SHA", KeyExchange: "ECDH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC00F: {ID: 0xC00F, Name: "TLS_ECDH_RSA_WITH_AES_256_CBC_SHA", KeyExchange: "ECDH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC010: {ID: 0xC010, Name: "TLS_ECDHE_RSA_WITH_NULL_SHA", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC011: {ID: 0xC011, Name: "TLS_ECDHE_RSA_WITH_RC4_128_SHA", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC012: {ID: 0xC012, Name: "TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC013: {ID: 0xC013, Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC014: {ID: 0xC014, Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC015: {ID: 0xC015, Name: "TLS_ECDH_anon_WITH_NULL_SHA", KeyExchange: "ECDH", Auth: "anon", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC016: {ID: 0xC016, Name: "TLS_ECDH_anon_WITH_RC4_128_SHA", KeyExchange: "ECDH", Auth: "anon", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC017: {ID: 0xC017, Name: "TLS_ECDH_anon_WITH_3DES_EDE_CBC_SHA", KeyExchange: "ECDH", Auth: "anon", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC018: {ID: 0xC018, Name: "TLS_ECDH_anon_WITH_AES_128_CBC_SHA", KeyExchange: "ECDH", Auth: "anon", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC019: {ID: 0xC019, Name: "TLS_ECDH_anon_WITH_AES_256_CBC_SHA", KeyExchange: "ECDH", Auth: "anon", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC01A: {ID: 0xC01A, Name: "TLS_SRP_SHA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "SRP", Auth: "SRP", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC01B: {ID: 0xC01B, Name: "TLS_SRP_SHA_RSA_WITH_3DES_EDE_CBC_SHA", KeyExchange: "SRP", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC01C: {ID: 0xC01C, Name: "TLS_SRP_SHA_DSS_WITH_3DES_EDE_CBC_SHA", KeyExchange: "SRP", Auth: "DSS", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC01D: {ID: 0xC01D, Name: "TLS_SRP_SHA_WITH_AES_128_CBC_SHA", KeyExchange: "SRP", Auth: "SRP", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC01E: {ID: 0xC01E, Name: "TLS_SRP_SHA_RSA_WITH_AES_128_CBC_SHA", KeyExchange: "SRP", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC01F: {ID: 0xC01F, Name: "TLS_SRP_SHA_DSS_WITH_AES_128_CBC_SHA", KeyExchange: "SRP", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC020: {ID: 0xC020, Name: "TLS_SRP_SHA_WITH_AES_256_CBC_SHA", KeyExchange: "SRP", Auth: "SRP", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC021: {ID: 0xC021, Name: "TLS_SRP_SHA_RSA_WITH_AES_256_CBC_SHA", KeyExchange: "SRP", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC022: {ID: 0xC022, Name: "TLS_SRP_SHA_DSS_WITH_AES_256_CBC_SHA", KeyExchange: "SRP", Auth: "DSS", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC023: {ID: 0xC023, Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC024: {ID: 0xC024, Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC025: {ID: 0xC025, Name: "TLS_ECDH_ECDSA_WITH_AES_128_CBC_SHA256", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC026: {ID: 0xC026, Name: "TLS_ECDH_ECDSA_WITH_AES_256_CBC_SHA384", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC027: {ID: 0xC027, Name: "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC028: {ID: 0xC028, Name: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC029: {ID: 0xC029, Name: "TLS_ECDH_RSA_WITH_AES_128_CBC_SHA256", KeyExchange: "ECDH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC02A: {ID: 0xC02A, Name: "TLS_ECDH_RSA_WITH_AES_256_CBC_SHA384", KeyExchange: "ECDH", Auth: "RSA", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC02B: {ID: 0xC02B, Name: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC02C: {ID: 0xC02C, Name: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC02D: {ID: 0xC02D, Name: "TLS_ECDH_ECDSA_WITH_AES_128_GCM_SHA256", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC02E: {ID: 0xC02E, Name: "TLS_ECDH_ECDSA_WITH_AES_256_GCM_SHA384", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC02F: {ID: 0xC02F, Name: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC030: {ID: 0xC030, Name: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC031: {ID: 0xC031, Name: "TLS_ECDH_RSA_WITH_AES_128_GCM_SHA256", KeyExchange: "ECDH", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC032: {ID: 0xC032, Name: "TLS_ECDH_RSA_WITH_AES_256_GCM_SHA384", KeyExchange: "ECDH", Auth: "RSA", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC033: {ID: 0xC033, Name: "TLS_ECDHE_PSK_WITH_RC4_128_SHA", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "RC4", Mode: "stream", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC034: {ID: 0xC034, Name: "TLS_ECDHE_PSK_WITH_3DES_EDE_CBC_SHA", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC035: {ID: 0xC035, Name: "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC036: {ID: 0xC036, Name: "TLS_ECDHE_PSK_WITH_AES_256_CBC_SHA", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC037: {ID: 0xC037, Name: "TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC038: {ID: 0xC038, Name: "TLS_ECDHE_PSK_WITH_AES_256_CBC_SHA384", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC039: {ID: 0xC039, Name: "TLS_ECDHE_PSK_WITH_NULL_SHA", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xC03A: {ID: 0xC03A, Name: "TLS_ECDHE_PSK_WITH_NULL_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC03B: {ID: 0xC03B, Name: "TLS_ECDHE_PSK_WITH_NULL_SHA384", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "NULL", MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC03C: {ID: 0xC03C, Name: "TLS_RSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC03D: {ID: 0xC03D, Name: "TLS_RSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "RSA", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC03E: {ID: 0xC03E, Name: "TLS_DH_DSS_WITH_ARIA_128_CBC_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC03F: {ID: 0xC03F, Name: "TLS_DH_DSS_WITH_ARIA_256_CBC_SHA384", KeyExchange: "DH", Auth: "DSS", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC040: {ID: 0xC040, Name: "TLS_DH_RSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC041: {ID: 0xC041, Name: "TLS_DH_RSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "DH", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC042: {ID: 0xC042, Name: "TLS_DHE_DSS_WITH_ARIA_128_CBC_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC043: {ID: 0xC043, Name: "TLS_DHE_DSS_WITH_ARIA_256_CBC_SHA384", KeyExchange: "DHE", Auth: "DSS", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC044: {ID: 0xC044, Name: "TLS_DHE_RSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC045: {ID: 0xC045, Name: "TLS_DHE_RSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "DHE", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC046: {ID: 0xC046, Name: "TLS_DH_anon_WITH_ARIA_128_CBC_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC047: {ID: 0xC047, Name: "TLS_DH_anon_WITH_ARIA_256_CBC_SHA384", KeyExchange: "DH", Auth: "anon", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC048: {ID: 0xC048, Name: "TLS_ECDHE_ECDSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC049: {ID: 0xC049, Name: "TLS_ECDHE_ECDSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC04A: {ID: 0xC04A, Name: "TLS_ECDH_ECDSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC04B: {ID: 0xC04B, Name: "TLS_ECDH_ECDSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC04C: {ID: 0xC04C, Name: "TLS_ECDHE_RSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC04D: {ID: 0xC04D, Name: "TLS_ECDHE_RSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC04E: {ID: 0xC04E, Name: "TLS_ECDH_RSA_WITH_ARIA_128_CBC_SHA256", KeyExchange: "ECDH", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC04F: {ID: 0xC04F, Name: "TLS_ECDH_RSA_WITH_ARIA_256_CBC_SHA384", KeyExchange: "ECDH", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC050: {ID: 0xC050, Name: "TLS_RSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC051: {ID: 0xC051, Name: "TLS_RSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "RSA", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC052: {ID: 0xC052, Name: "TLS_DHE_RSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC053: {ID: 0xC053, Name: "TLS_DHE_RSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "DHE", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC054: {ID: 0xC054, Name: "TLS_DH_RSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC055: {ID: 0xC055, Name: "TLS_DH_RSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "DH", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC056: {ID: 0xC056, Name: "TLS_DHE_DSS_WITH_ARIA_128_GCM_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC057: {ID: 0xC057, Name: "TLS_DHE_DSS_WITH_ARIA_256_GCM_SHA384", KeyExchange: "DHE", Auth: "DSS", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC058: {ID: 0xC058, Name: "TLS_DH_DSS_WITH_ARIA_128_GCM_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC059: {ID: 0xC059, Name: "TLS_DH_DSS_WITH_ARIA_256_GCM_SHA384", KeyExchange: "DH", Auth: "DSS", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC05A: {ID: 0xC05A, Name: "TLS_DH_anon_WITH_ARIA_128_GCM_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC05B: {ID: 0xC05B, Name: "TLS_DH_anon_WITH_ARIA_256_GCM_SHA384", KeyExchange: "DH", Auth: "anon", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC05C: {ID: 0xC05C, Name: "TLS_ECDHE_ECDSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC05D: {ID: 0xC05D, Name: "TLS_ECDHE_ECDSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC05E: {ID: 0xC05E, Name: "TLS_ECDH_ECDSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC05F: {ID: 0xC05F, Name: "TLS_ECDH_ECDSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC060: {ID: 0xC060, Name: "TLS_ECDHE_RSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC061: {ID: 0xC061, Name: "TLS_ECDHE_RSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC062: {ID: 0xC062, Name: "TLS_ECDH_RSA_WITH_ARIA_128_GCM_SHA256", KeyExchange: "ECDH", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC063: {ID: 0xC063, Name: "TLS_ECDH_RSA_WITH_ARIA_256_GCM_SHA384", KeyExchange: "ECDH", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC064: {ID: 0xC064, Name: "TLS_PSK_WITH_ARIA_128_CBC_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC065: {ID: 0xC065, Name: "TLS_PSK_WITH_ARIA_256_CBC_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC066: {ID: 0xC066, Name: "TLS_DHE_PSK_WITH_ARIA_128_CBC_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC067: {ID: 0xC067, Name: "TLS_DHE_PSK_WITH_ARIA_256_CBC_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC068: {ID: 0xC068, Name: "TLS_RSA_PSK_WITH_ARIA_128_CBC_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC069: {ID: 0xC069, Name: "TLS_RSA_PSK_WITH_ARIA_256_CBC_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC06A: {ID: 0xC06A, Name: "TLS_PSK_WITH_ARIA_128_GCM_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC06B: {ID: 0xC06B, Name: "TLS_PSK_WITH_ARIA_256_GCM_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC06C: {ID: 0xC06C, Name: "TLS_DHE_PSK_WITH_ARIA_128_GCM_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC06D: {ID: 0xC06D, Name: "TLS_DHE_PSK_WITH_ARIA_256_GCM_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC06E: {ID: 0xC06E, Name: "TLS_RSA_PSK_WITH_ARIA_128_GCM_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC06F: {ID: 0xC06F, Name: "TLS_RSA_PSK_WITH_ARIA_256_GCM_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "ARIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC070: {ID: 0xC070, Name: "TLS_ECDHE_PSK_WITH_ARIA_128_CBC_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "ARIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC071: {ID: 0xC071, Name: "TLS_ECDHE_PSK_WITH_ARIA_256_CBC_SHA384", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "ARIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC072: {ID: 0xC072, Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC073: {ID: 0xC073, Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC074: {ID: 0xC074, Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC075: {ID: 0xC075, Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC076: {ID: 0xC076, Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC077: {ID: 0xC077, Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC078: {ID: 0xC078, Name: "TLS_ECDH_RSA_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "ECDH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC079: {ID: 0xC079, Name: "TLS_ECDH_RSA_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "ECDH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC07A: {ID: 0xC07A, Name: "TLS_RSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "RSA", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC07B: {ID: 0xC07B, Name: "TLS_RSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "RSA", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC07C: {ID: 0xC07C, Name: "TLS_DHE_RSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC07D: {ID: 0xC07D, Name: "TLS_DHE_RSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "DHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC07E: {ID: 0xC07E, Name: "TLS_DH_RSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "DH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC07F: {ID: 0xC07F, Name: "TLS_DH_RSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "DH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC080: {ID: 0xC080, Name: "TLS_DHE_DSS_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "DHE", Auth: "DSS", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC081: {ID: 0xC081, Name: "TLS_DHE_DSS_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "DHE", Auth: "DSS", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC082: {ID: 0xC082, Name: "TLS_DH_DSS_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "DH", Auth: "DSS", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC083: {ID: 0xC083, Name: "TLS_DH_DSS_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "DH", Auth: "DSS", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC084: {ID: 0xC084, Name: "TLS_DH_anon_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "DH", Auth: "anon", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC085: {ID: 0xC085, Name: "TLS_DH_anon_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "DH", Auth: "anon", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC086: {ID: 0xC086, Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC087: {ID: 0xC087, Name: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC088: {ID: 0xC088, Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC089: {ID: 0xC089, Name: "TLS_ECDH_ECDSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "ECDH", Auth: "ECDSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC08A: {ID: 0xC08A, Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC08B: {ID: 0xC08B, Name: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC08C: {ID: 0xC08C, Name: "TLS_ECDH_RSA_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "ECDH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC08D: {ID: 0xC08D, Name: "TLS_ECDH_RSA_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "ECDH", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC08E: {ID: 0xC08E, Name: "TLS_PSK_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC08F: {ID: 0xC08F, Name: "TLS_PSK_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC090: {ID: 0xC090, Name: "TLS_DHE_PSK_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC091: {ID: 0xC091, Name: "TLS_DHE_PSK_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC092: {ID: 0xC092, Name: "TLS_RSA_PSK_WITH_CAMELLIA_128_GCM_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC093: {ID: 0xC093, Name: "TLS_RSA_PSK_WITH_CAMELLIA_256_GCM_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "CAMELLIA", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC094: {ID: 0xC094, Name: "TLS_PSK_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC095: {ID: 0xC095, Name: "TLS_PSK_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC096: {ID: 0xC096, Name: "TLS_DHE_PSK_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC097: {ID: 0xC097, Name: "TLS_DHE_PSK_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC098: {ID: 0xC098, Name: "TLS_RSA_PSK_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC099: {ID: 0xC099, Name: "TLS_RSA_PSK_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC09A: {ID: 0xC09A, Name: "TLS_ECDHE_PSK_WITH_CAMELLIA_128_CBC_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 128, MAC: "SHA256", MinVersion: tls.VersionTLS12},
+	0xC09B: {ID: 0xC09B, Name: "TLS_ECDHE_PSK_WITH_CAMELLIA_256_CBC_SHA384", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "CAMELLIA", Mode: "CBC", KeyBits: 256, MAC: "SHA384", MinVersion: tls.VersionTLS12},
+	0xC09C: {ID: 0xC09C, Name: "TLS_RSA_WITH_AES_128_CCM", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC09D: {ID: 0xC09D, Name: "TLS_RSA_WITH_AES_256_CCM", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CCM", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC09E: {ID: 0xC09E, Name: "TLS_DHE_RSA_WITH_AES_128_CCM", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC09F: {ID: 0xC09F, Name: "TLS_DHE_RSA_WITH_AES_256_CCM", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CCM", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC0A0: {ID: 0xC0A0, Name: "TLS_RSA_WITH_AES_128_CCM_8", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A1: {ID: 0xC0A1, Name: "TLS_RSA_WITH_AES_256_CCM_8", KeyExchange: "RSA", Auth: "RSA", Cipher: "AES", Mode: "CCM_8", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A2: {ID: 0xC0A2, Name: "TLS_DHE_RSA_WITH_AES_128_CCM_8", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A3: {ID: 0xC0A3, Name: "TLS_DHE_RSA_WITH_AES_256_CCM_8", KeyExchange: "DHE", Auth: "RSA", Cipher: "AES", Mode: "CCM_8", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A4: {ID: 0xC0A4, Name: "TLS_PSK_WITH_AES_128_CCM", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A5: {ID: 0xC0A5, Name: "TLS_PSK_WITH_AES_256_CCM", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A6: {ID: 0xC0A6, Name: "TLS_DHE_PSK_WITH_AES_128_CCM", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC0A7: {ID: 0xC0A7, Name: "TLS_DHE_PSK_WITH_AES_256_CCM", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC0A8: {ID: 0xC0A8, Name: "TLS_PSK_WITH_AES_128_CCM_8", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0A9: {ID: 0xC0A9, Name: "TLS_PSK_WITH_AES_256_CCM_8", KeyExchange: "PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM_8", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0AA: {ID: 0xC0AA, Name: "TLS_PSK_DHE_WITH_AES_128_CCM_8", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0AB: {ID: 0xC0AB, Name: "TLS_PSK_DHE_WITH_AES_256_CCM_8", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM_8", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0AC: {ID: 0xC0AC, Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC0AD: {ID: 0xC0AD, Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CCM", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xC0AE: {ID: 0xC0AE, Name: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0AF: {ID: 0xC0AF, Name: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM_8", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "AES", Mode: "CCM_8", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0B0: {ID: 0xC0B0, Name: "TLS_ECCPWD_WITH_AES_128_GCM_SHA256", KeyExchange: "ECCPWD", Auth: "ECCPWD", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0B1: {ID: 0xC0B1, Name: "TLS_ECCPWD_WITH_AES_256_GCM_SHA384", KeyExchange: "ECCPWD", Auth: "ECCPWD", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0B2: {ID: 0xC0B2, Name: "TLS_ECCPWD_WITH_AES_128_CCM_SHA256", KeyExchange: "ECCPWD", Auth: "ECCPWD", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0B3: {ID: 0xC0B3, Name: "TLS_ECCPWD_WITH_AES_256_CCM_SHA384", KeyExchange: "ECCPWD", Auth: "ECCPWD", Cipher: "AES", Mode: "CCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xC0B4: {ID: 0xC0B4, Name: "TLS_SHA256_SHA256", Cipher: "NULL", MAC: "SHA256", MinVersion: tls.VersionTLS13},
+	0xC0B5: {ID: 0xC0B5, Name: "TLS_SHA384_SHA384", Cipher: "NULL", MAC: "SHA384", MinVersion: tls.VersionTLS13},
+	0xC100: {ID: 0xC100, Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_CTR_OMAC", KeyExchange: "GOSTR341112_256", Auth: "GOSTR341112_256", Cipher: "KUZNYECHIK", Mode: "CTR", KeyBits: 256, MAC: "OMAC", MinVersion: tls.VersionTLS12},
+	0xC101: {ID: 0xC101, Name: "TLS_GOSTR341112_256_WITH_MAGMA_CTR_OMAC", KeyExchange: "GOSTR341112_256", Auth: "GOSTR341112_256", Cipher: "MAGMA", Mode: "CTR", KeyBits: 256, MAC: "OMAC", MinVersion: tls.VersionTLS12},
+	0xC102: {ID: 0xC102, Name: "TLS_GOSTR341112_256_WITH_28147_CNT_IMIT", KeyExchange: "GOSTR341112_256", Auth: "GOSTR341112_256", Cipher: "28147", Mode: "CNT", KeyBits: 256, MAC: "IMIT", MinVersion: tls.VersionTLS12},
+	0xC103: {ID: 0xC103, Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_MGM_L", Cipher: "KUZNYECHIK", Mode: "MGM", KeyBits: 256, MAC: "STREEBOG256", AEAD: true, MinVersion: tls.VersionTLS13},
+	0xC104: {ID: 0xC104, Name: "TLS_GOSTR341112_256_WITH_MAGMA_MGM_L", Cipher: "MAGMA", Mode: "MGM", KeyBits: 256, MAC: "STREEBOG256", AEAD: true, MinVersion: tls.VersionTLS13},
+	0xC105: {ID: 0xC105, Name: "TLS_GOSTR341112_256_WITH_KUZNYECHIK_MGM_S", Cipher: "KUZNYECHIK", Mode: "MGM", KeyBits: 256, MAC: "STREEBOG256", AEAD: true, MinVersion: tls.VersionTLS13},
+	0xC106: {ID: 0xC106, Name: "TLS_GOSTR341112_256_WITH_MAGMA_MGM_S", Cipher: "MAGMA", Mode: "MGM", KeyBits: 256, MAC: "STREEBOG256", AEAD: true, MinVersion: tls.VersionTLS13},
+	0xCACA: {ID: 0xCACA, Name: "TLS_GREASE_CA", Signaling: true},
+	0xCC13: {ID: 0xCC13, Name: "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256_OLD", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xCC14: {ID: 0xCC14, Name: "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256_OLD", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xCC15: {ID: 0xCC15, Name: "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256_OLD", KeyExchange: "DHE", Auth: "RSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xCCA8: {ID: 0xCCA8, Name: "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "ECDHE", Auth: "RSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xCCA9: {ID: 0xCCA9, Name: "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "ECDHE", Auth: "ECDSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xCCAA: {ID: 0xCCAA, Name: "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "DHE", Auth: "RSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xCCAB: {ID: 0xCCAB, Name: "TLS_PSK_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "PSK", Auth: "PSK", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xCCAC: {ID: 0xCCAC, Name: "TLS_ECDHE_PSK_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xCCAD: {ID: 0xCCAD, Name: "TLS_DHE_PSK_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "DHE_PSK", Auth: "PSK", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xCCAE: {ID: 0xCCAE, Name: "TLS_RSA_PSK_WITH_CHACHA20_POLY1305_SHA256", KeyExchange: "RSA_PSK", Auth: "RSA", Cipher: "CHACHA20", Mode: "POLY1305", KeyBits: 256, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xD001: {ID: 0xD001, Name: "TLS_ECDHE_PSK_WITH_AES_128_GCM_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "GCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xD002: {ID: 0xD002, Name: "TLS_ECDHE_PSK_WITH_AES_256_GCM_SHA384", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "GCM", KeyBits: 256, MAC: "SHA384", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xD003: {ID: 0xD003, Name: "TLS_ECDHE_PSK_WITH_AES_128_CCM_8_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM_8", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12},
+	0xD005: {ID: 0xD005, Name: "TLS_ECDHE_PSK_WITH_AES_128_CCM_SHA256", KeyExchange: "ECDHE_PSK", Auth: "PSK", Cipher: "AES", Mode: "CCM", KeyBits: 128, MAC: "SHA256", AEAD: true, MinVersion: tls.VersionTLS12, Recommended: true},
+	0xDADA: {ID: 0xDADA, Name: "TLS_GREASE_DA", Signaling: true},
+	0xEAEA: {ID: 0xEAEA, Name: "TLS_GREASE_EA", Signaling: true},
+	0xFAFA: {ID: 0xFAFA, Name: "TLS_GREASE_FA", Signaling: true},
+	0xFEFE: {ID: 0xFEFE, Name: "SSL_RSA_FIPS_WITH_DES_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "DES", Mode: "CBC", KeyBits: 56, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xFEFF: {ID: 0xFEFF, Name: "SSL_RSA_FIPS_WITH_3DES_EDE_CBC_SHA", KeyExchange: "RSA", Auth: "RSA", Cipher: "3DES_EDE", Mode: "CBC", KeyBits: 168, MAC: "SHA", MinVersion: tls.VersionTLS10},
+	0xFF85: {ID: 0xFF85, Name: "TLS_GOST2012256-GOST89-GOST89", KeyExchange: "GOSTR341112_256", Auth: "GOSTR341112_256", Cipher: "28147", Mode: "CNT", KeyBits: 256, MAC: "IMIT", MinVersion: tls.VersionTLS12},
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func TestCipherSuiteRegistry(t *testing.T) {
+	tests := []struct {
+		id            uint16
+		name          string
+		forwardSecret bool
+		weak          []string
+		broken        []string
+	}{
+		{tls.TLS_AES_128_GCM_SHA256, "TLS_AES_128_GCM_SHA256", true, nil, nil},
+		{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", true, nil, nil},
+		{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA", true, []string{cbcReason}, nil},
+		{tls.TLS_RSA_WITH_AES_128_CBC_SHA, "TLS_RSA_WITH_AES_128_CBC_SHA", false, []string{cbcReason, noEphemeralReason}, nil},
+		{tls.TLS_RSA_WITH_RC4_128_SHA, "TLS_RSA_WITH_RC4_128_SHA", false, []string{noEphemeralReason}, []string{rc4Reason}},
+		{0x0003, "TLS_RSA_EXPORT_WITH_RC4_40_MD5", false, []string{noEphemeralReason}, []string{fewBitReason, rc4Reason}},
+		{0x0018, "TLS_DH_anon_WITH_RC4_128_MD5", false, nil, []string{nullAuthReason, rc4Reason}},
+		{0x0002, "TLS_RSA_WITH_NULL_SHA", false, []string{noEphemeralReason}, []string{nullReason}},
+		{0xC0B4, "TLS_SHA256_SHA256", true, nil, []string{nullReason}},
+		{0xCCAD, "TLS_DHE_PSK_WITH_CHACHA20_POLY1305_SHA256", true, nil, nil},
+		{0xFEFE, "SSL_RSA_FIPS_WITH_DES_CBC_SHA", false, []string{cbcReason, noEphemeralReason}, []string{fewBitReason}},
+		{0x00FF, "TLS_EMPTY_RENEGOTIATION_INFO_SCSV", false, nil, nil},
+		{0x5A5A, "TLS_GREASE_5A", false, nil, nil},
+	}
+	for _, tt := range tests {
+		cs, ok := cipherSuites[tt.id]
+		if !ok {
+			t.Errorf("%#04x: not in the registry", tt.id)
+			continue
+		}
+		if cs.ID != tt.id || cs.Name != tt.name {
+			t.Errorf("%#04x: want %#04x %s, got %#04x %s", tt.id, tt.id, tt.name, cs.ID, cs.Name)
+		}
+		if got := cs.forwardSecret(); got != tt.forwardSecret {
+			t.Errorf("%s: forwardSecret: want %t, got %t", tt.name, tt.forwardSecret, got)
+		}
+		if got := cs.weakReasons(); !reflect.DeepEqual(got, tt.weak) {
+			t.Errorf("%s: weakReasons:\nwant %q\ngot  %q", tt.name, tt.weak, got)
+		}
+		if got := cs.brokenReasons(); !reflect.DeepEqual(got, tt.broken) {
+			t.Errorf("%s: brokenReasons:\nwant %q\ngot  %q", tt.name, tt.broken, got)
+		}
+	}
+}
+
+func TestCipherSuiteName(t *testing.T) {
+	if got, want := cipherSuiteName(tls.TLS_CHACHA20_POLY1305_SHA256), "TLS_CHACHA20_POLY1305_SHA256"; got != want {
+		t.Errorf("cipherSuiteName: want %q, got %q", want, got)
+	}
+	if got, want := cipherSuiteName(0x0F0F), "An unknown cipher suite: 0x0f0f"; got != want {
+		t.Errorf("cipherSuiteName: want %q, got %q", want, got)
+	}
+}
@@ -27,6 +27,7 @@ const (
 	VersionTLS10 = 0x0301
 	VersionTLS11 = 0x0302
 	VersionTLS12 = 0x0303
+	VersionTLS13 = 0x0304
 )
 
 const (
@@ -37,7 +38,11 @@ const (
 	maxWarnAlertCount = 5            // maximum number of consecutive warning alerts
 
 	minVersion = VersionTLS10
-	maxVersion = VersionTLS12
+	maxVersion = VersionTLS13
+
+	// maxClientVersion is the highest version the client side of this
+	// package will offer. Only the server speaks TLS 1.3.
+	maxClientVersion = VersionTLS12
 )
 
 // TLS record types.
@@ -52,19 +57,22 @@ const (
 
 // TLS handshake message types.
 const (
-	typeHelloRequest       uint8 = 0
-	typeClientHello        uint8 = 1
-	typeServerHello        uint8 = 2
-	typeNewSessionTicket   uint8 = 4
-	typeCertificate        uint8 = 11
-	typeServerKeyExchange  uint8 = 12
-	typeCertificateRequest uint8 = 13
-	typeServerHelloDone    uint8 = 14
-	typeCertificateVerify  uint8 = 15
-	typeClientKeyExchange  uint8 = 16
-	typeFinished           uint8 = 20
-	typeCertificateStatus  uint8 = 22
-	typeNextProtocol       uint8 = 67 // Not IANA assigned
+	typeHelloRequest        uint8 = 0
+	typeClientHello         uint8 = 1
+	typeServerHello         uint8 = 2
+	typeNewSessionTicket    uint8 = 4
+	typeEncryptedExtensions uint8 = 8
+	typeCertificate         uint8 = 11
+	typeServerKeyExchange   uint8 = 12
+	typeCertificateRequest  uint8 = 13
+	typeServerHelloDone     uint8 = 14
+	typeCertificateVerify   uint8 = 15
+	typeClientKeyExchange   uint8 = 16
+	typeFinished            uint8 = 20
+	typeCertificateStatus   uint8 = 22
+	typeKeyUpdate           uint8 = 24
+	typeNextProtocol        uint8 = 67  // Not IANA assigned
+	typeMessageHash         uint8 = 254 // synthetic message
 )
 
 // TLS compression types.
@@ -87,6 +95,19 @@ const (
 
 	// added for howsmyssl's early TLS 1.3 support
 	extensionSupportedVersions uint16 = 43
+
+	// Added for howsmyssl's use. Only recorded, never negotiated.
+	extensionEncryptThenMAC          uint16 = 22
+	extensionExtendedMasterSecret    uint16 = 23
+	extensionSignatureAlgorithmsCert uint16 = 50
+	extensionEncryptedClientHello    uint16 = 0xfe0d
+
+	// added for the TLS 1.3 server handshake
+	extensionPreSharedKey uint16 = 41
+	extensionEarlyData    uint16 = 42
+	extensionCookie       uint16 = 44
+	extensionPSKModes     uint16 = 45
+	extensionKeyShare     uint16 = 51
 )
 
 // TLS signaling cipher suite values
@@ -105,6 +126,35 @@ const (
 	X25519    CurveID = 29
 )
 
+// TLS 1.3 Key Share. See https://tools.ietf.org/html/rfc8446#section-4.2.8
+type keyShare struct {
+	group CurveID
+	data  []byte
+}
+
+// TLS 1.3 PSK Key Exchange Modes. See https://tools.ietf.org/html/rfc8446#section-4.2.9
+const (
+	pskModePlain uint8 = 0
+	pskModeDHE   uint8 = 1
+)
+
+// helloRetryRequestRandom is set as the Random value of a ServerHello
+// to signal that the message is actually a HelloRetryRequest.
+var helloRetryRequestRandom = []byte{ // See RFC 8446, Section 4.1.3.
+	0xCF, 0x21, 0xAD, 0x74, 0xE5, 0x9A, 0x61, 0x11,
+	0xBE, 0x1D, 0x8C, 0x02, 0x1E, 0x65, 0xB8, 0x91,
+	0xC2, 0xA2, 0x11, 0x16, 0x7A, 0xBB, 0x8C, 0x5E,
+	0x07, 0x9E, 0x09, 0xE2, 0xC8, 0xA8, 0x33, 0x9C,
+}
+
+const (
+	// downgradeCanaryTLS12 or downgradeCanaryTLS11 is embedded in the server
+	// random as a downgrade protection if the server would be capable of
+	// negotiating a higher version. See RFC 8446, Section 4.1.3.
+	downgradeCanaryTLS12 = "DOWNGRD\x01"
+	downgradeCanaryTLS11 = "DOWNGRD\x00"
+)
+
 // TLS Elliptic Curve Point Formats
 // http://www.iana.org/assignments/tls-parameters/tls-parameters.xml#tls-parameters-9
 const (
@@ -175,12 +225,57 @@ type ConnectionState struct {
 	TLSUnique []byte
 
 	// Added for howsmyssl's use
+	CurveID                          CurveID         // group used for the ECDHE key exchange, or 0 if none (server side only)
+	DHGroupBits                      int             // size of the DHE group's prime, or 0 if DHE wasn't used (server side only)
+	SignatureScheme                  SignatureScheme // scheme the server signed the handshake with, or 0 if none or before TLS 1.2 (server side only)
 	ClientCipherSuites               []uint16
 	CompressionMethods               []uint8
 	NMinusOneRecordSplittingDetected bool
 	AbleToDetectNMinusOneSplitting   bool
 	SessionTicketsSupported          bool
 	SupportedVersions                []uint16
+	ClientHello                      []byte                 // raw ClientHello handshake message, including its header
+	ClientHelloExtensions            []ClientHelloExtension // every ClientHello extension, in the order sent
+	ClientVersion                    uint16                 // legacy_version field of the ClientHello
+	ClientCurves                     []CurveID              // supported_groups, in the order sent
+	ClientPointFormats               []uint8                // ec_point_formats, in the order sent
+	ClientALPNProtocols              []string               // application_layer_protocol_negotiation, in the order sent
+	ClientSignatureAlgorithms        []SignatureScheme      // signature_algorithms, in the order sent
+	ClientSignatureAlgorithmsCert    []SignatureScheme      // signature_algorithms_cert, in the order sent
+	ClientKeyShareGroups             []CurveID              // groups of the key_share entries, in the order sent
+	ClientExtendedMasterSecret       bool                   // extended_master_secret was sent (RFC 7627)
+	ClientEncryptThenMAC             bool                   // encrypt_then_mac was sent (RFC 7366)
+	ClientRenegotiationInfo          bool                   // renegotiation_info was sent (RFC 5746)
+	ClientRenegotiationSCSV          bool                   // TLS_EMPTY_RENEGOTIATION_INFO_SCSV was sent (RFC 5746)
+	ClientEncryptedClientHello       *EncryptedClientHello  // encrypted_client_hello, or nil if it wasn't sent
+	ClientFallbackSCSV               bool                   // TLS_FALLBACK_SCSV was sent (RFC 7507)
+}
+
+// ClientHelloExtension is a single extension from a ClientHello, whether or
+// not this package understands it. Added for howsmyssl's use.
+type ClientHelloExtension struct {
+	Type uint16
+	Data []byte // extension_data, without the type and length
+}
+
+// ECH ClientHello types from draft-ietf-tls-esni. Added for howsmyssl's use.
+const (
+	ECHClientHelloOuter uint8 = 0
+	ECHClientHelloInner uint8 = 1
+)
+
+// EncryptedClientHello is the parsed encrypted_client_hello extension of a
+// ClientHello. The server holds no ECH keys, so the payload is never
+// decrypted. Added for howsmyssl's use.
+type EncryptedClientHello struct {
+	Type uint8 // ECHClientHelloOuter or ECHClientHelloInner
+
+	// The remaining fields are only set for ECHClientHelloOuter.
+	KDF           uint16 // HPKE KDF identifier
+	AEAD          uint16 // HPKE AEAD identifier
+	ConfigID      uint8
+	EncLength     int // length of the encapsulated HPKE key
+	PayloadLength int // length of the encrypted ClientHelloInner
 }
 
 // ClientAuthType declares the policy the server will follow for
@@ -454,8 +549,10 @@ type Config struct {
 	// This should be used only for testing.
 	InsecureSkipVerify bool
 
-	// CipherSuites is a list of supported cipher suites. If CipherSuites
-	// is nil, TLS uses a list of suites supported by the implementation.
+	// CipherSuites is a list of supported cipher suites for TLS versions up
+	// to TLS 1.2. If CipherSuites is nil, TLS uses a list of suites
+	// supported by the implementation. The TLS 1.3 cipher suites are not
+	// configurable.
 	CipherSuites []uint16
 
 	// PreferServerCipherSuites controls whether the server selects the
@@ -488,7 +585,7 @@ type Config struct {
 
 	// MaxVersion contains the maximum SSL/TLS version that is acceptable.
 	// If zero, then the maximum version supported by this package is used,
-	// which is currently TLS 1.2.
+	// which is currently TLS 1.3 for servers and TLS 1.2 for clients.
 	MaxVersion uint16
 
 	// CurvePreferences contains the elliptic curves that will be used in
@@ -514,6 +611,19 @@ type Config struct {
 	// used for debugging.
 	KeyLogWriter io.Writer
 
+	// AllowInappropriateFallback, if true, lets the handshake continue
+	// when a client sends TLS_FALLBACK_SCSV with a version below the
+	// server's maximum instead of sending an inappropriate_fallback alert,
+	// so the fallback can be reported. See RFC 7507. Added for howsmyssl's
+	// use.
+	AllowInappropriateFallback bool
+
+	// DHGroupBits is the size of the finite field group used by the DHE
+	// cipher suites: 512, 768, 1024 or 2048. The groups smaller than 2048
+	// bits are insecure and are only there to probe which sizes clients
+	// accept. If zero, 2048 is used. Added for howsmyssl's use.
+	DHGroupBits int
+
 	serverInitOnce sync.Once // guards calling (*Config).serverInit
 
 	// mutex protects sessionTicketKeys.
@@ -587,6 +697,8 @@ func (c *Config) Clone() *Config {
 		DynamicRecordSizingDisabled: c.DynamicRecordSizingDisabled,
 		Renegotiation:               c.Renegotiation,
 		KeyLogWriter:                c.KeyLogWriter,
+		AllowInappropriateFallback:  c.AllowInappropriateFallback,
+		DHGroupBits:                 c.DHGroupBits,
 		sessionTicketKeys:           sessionTicketKeys,
 	}
 }
@@ -701,11 +813,29 @@ func (c *Config) curvePreferences() []CurveID {
 	return c.CurvePreferences
 }
 
+// serverCurvePreferences is curvePreferences without the groups the server
+// can't do key agreement with, which the client side is free to offer
+// anyway.
+func (c *Config) serverCurvePreferences() []CurveID {
+	var curves []CurveID
+	for _, curve := range c.curvePreferences() {
+		if SupportsCurve(curve) {
+			curves = append(curves, curve)
+		}
+	}
+	return curves
+}
+
 // mutualVersion returns the protocol version to use given the advertised
-// version of the peer.
+// legacy version of the peer. TLS 1.3 is only ever negotiated through the
+// supported_versions extension (see supportsTLS13), so the result is never
+// above TLS 1.2.
 func (c *Config) mutualVersion(vers uint16) (uint16, bool) {
 	minVersion := c.minVersion()
 	maxVersion := c.maxVersion()
+	if maxVersion > VersionTLS12 {
+		maxVersion = VersionTLS12
+	}
 
 	if vers < minVersion {
 		return 0, false
@@ -716,6 +846,34 @@ func (c *Config) mutualVersion(vers uint16) (uint16, bool) {
 	return vers, true
 }
 
+// supportsTLS13 reports whether a server using c can negotiate TLS 1.3.
+// Client certificates are not implemented for TLS 1.3, so configs that ask
+// for them stay on TLS 1.2 rather than silently skipping authentication.
+func (c *Config) supportsTLS13() bool {
+	return c.maxVersion() >= VersionTLS13 && c.minVersion() <= VersionTLS13 &&
+		c.ClientAuth == NoClientCert
+}
+
+// serverMaxVersion returns the highest protocol version a server using c
+// will actually negotiate.
+func (c *Config) serverMaxVersion() uint16 {
+	vers := c.maxVersion()
+	if vers > VersionTLS12 && !c.supportsTLS13() {
+		vers = VersionTLS12
+	}
+	return vers
+}
+
+// clientMaxVersion returns the highest protocol version a client using c
+// will offer.
+func (c *Config) clientMaxVersion() uint16 {
+	vers := c.maxVersion()
+	if vers > maxClientVersion {
+		vers = maxClientVersion
+	}
+	return vers
+}
+
 // getCertificate returns the best certificate for the given ClientHelloInfo,
 // defaulting to the first element of c.Certificates.
 func (c *Config) getCertificate(clientHello *ClientHelloInfo) (*Certificate, error) {
@@ -780,14 +938,22 @@ func (c *Config) BuildNameToCertificate() {
 	}
 }
 
-// writeKeyLog logs client random and master secret if logging was enabled by
-// setting c.KeyLogWriter.
-func (c *Config) writeKeyLog(clientRandom, masterSecret []byte) error {
+const (
+	keyLogLabelTLS12           = "CLIENT_RANDOM"
+	keyLogLabelClientHandshake = "CLIENT_HANDSHAKE_TRAFFIC_SECRET"
+	keyLogLabelServerHandshake = "SERVER_HANDSHAKE_TRAFFIC_SECRET"
+	keyLogLabelClientTraffic   = "CLIENT_TRAFFIC_SECRET_0"
+	keyLogLabelServerTraffic   = "SERVER_TRAFFIC_SECRET_0"
+)
+
+// writeKeyLog logs client random and the given secret under label if logging
+// was enabled by setting c.KeyLogWriter.
+func (c *Config) writeKeyLog(label string, clientRandom, secret []byte) error {
 	if c.KeyLogWriter == nil {
 		return nil
 	}
 
-	logLine := []byte(fmt.Sprintf("CLIENT_RANDOM %x %x\n", clientRandom, masterSecret))
+	logLine := []byte(fmt.Sprintf("%s %x %x\n", label, clientRandom, secret))
 
 	writerMutex.Lock()
 	_, err := c.KeyLogWriter.Write(logLine)
@@ -912,8 +1078,9 @@ func defaultConfig() *Config {
 }
 
 var (
-	once                   sync.Once
-	varDefaultCipherSuites []uint16
+	once                        sync.Once
+	varDefaultCipherSuites      []uint16
+	varDefaultCipherSuitesTLS13 []uint16
 )
 
 func defaultCipherSuites() []uint16 {
@@ -921,11 +1088,23 @@ func defaultCipherSuites() []uint16 {
 	return varDefaultCipherSuites
 }
 
+// defaultCipherSuitesTLS13 returns the server's preference order for the TLS
+// 1.3 cipher suites, which aren't configurable.
+func defaultCipherSuitesTLS13() []uint16 {
+	once.Do(initDefaultCipherSuites)
+	return varDefaultCipherSuitesTLS13
+}
+
 func initDefaultCipherSuites() {
 	var topCipherSuites []uint16
 	if cipherhw.AESGCMSupport() {
 		// If AES-GCM hardware is provided then prioritise AES-GCM
 		// cipher suites.
+		varDefaultCipherSuitesTLS13 = []uint16{
+			TLS_AES_128_GCM_SHA256,
+			TLS_CHACHA20_POLY1305_SHA256,
+			TLS_AES_256_GCM_SHA384,
+		}
 		topCipherSuites = []uint16{
 			TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
 			TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
@@ -937,6 +1116,11 @@ func initDefaultCipherSuites() {
 	} else {
 		// Without AES-GCM hardware, we put the ChaCha20-Poly1305
 		// cipher suites first.
+		varDefaultCipherSuitesTLS13 = []uint16{
+			TLS_CHACHA20_POLY1305_SHA256,
+			TLS_AES_128_GCM_SHA256,
+			TLS_AES_256_GCM_SHA384,
+		}
 		topCipherSuites = []uint16{
 			TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
 			TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
@@ -0,0 +1,79 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMutualVersion(t *testing.T) {
+	tests := []struct {
+		config *Config
+		vers   uint16
+		want   uint16
+		wantOk bool
+	}{
+		{&Config{}, VersionTLS12, VersionTLS12, true},
+		// TLS 1.3 is never negotiated from the legacy version field.
+		{&Config{}, VersionTLS13, VersionTLS12, true},
+		{&Config{}, 0x0305, VersionTLS12, true},
+		{&Config{MaxVersion: VersionTLS11}, VersionTLS12, VersionTLS11, true},
+		{&Config{}, VersionSSL30, 0, false},
+		{&Config{MinVersion: VersionTLS13}, VersionTLS12, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.config.mutualVersion(tt.vers)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("Config{MinVersion: %#04x, MaxVersion: %#04x}.mutualVersion(%#04x) = %#04x, %v; want %#04x, %v",
+				tt.config.MinVersion, tt.config.MaxVersion, tt.vers, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestSupportsTLS13(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *Config
+		want       bool
+		wantServer uint16
+	}{
+		{"default", &Config{}, true, VersionTLS13},
+		{"MaxVersion TLS 1.2", &Config{MaxVersion: VersionTLS12}, false, VersionTLS12},
+		{"MinVersion TLS 1.3", &Config{MinVersion: VersionTLS13}, true, VersionTLS13},
+		{"client certificates", &Config{ClientAuth: RequireAnyClientCert}, false, VersionTLS12},
+	}
+	for _, tt := range tests {
+		if got := tt.config.supportsTLS13(); got != tt.want {
+			t.Errorf("%s: supportsTLS13() = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.config.serverMaxVersion(); got != tt.wantServer {
+			t.Errorf("%s: serverMaxVersion() = %#04x, want %#04x", tt.name, got, tt.wantServer)
+		}
+		if got := tt.config.clientMaxVersion(); got > VersionTLS12 {
+			t.Errorf("%s: clientMaxVersion() = %#04x, want at most TLS 1.2", tt.name, got)
+		}
+	}
+}
+
+func TestWriteKeyLog(t *testing.T) {
+	var buf bytes.Buffer
+	config := &Config{KeyLogWriter: &buf}
+	if err := config.writeKeyLog(keyLogLabelTLS12, []byte{0x01, 0x02}, []byte{0xab, 0xcd}); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.writeKeyLog(keyLogLabelServerTraffic, []byte{0x03}, []byte{0xef}); err != nil {
+		t.Fatal(err)
+	}
+	want := "CLIENT_RANDOM 0102 abcd\nSERVER_TRAFFIC_SECRET_0 03 ef\n"
+	if got := buf.String(); got != want {
+		t.Errorf("key log = %q, want %q", got, want)
+	}
+
+	// Without a KeyLogWriter nothing is written and nothing fails.
+	if err := (&Config{}).writeKeyLog(keyLogLabelTLS12, nil, nil); err != nil {
+		t.Errorf("writeKeyLog without a KeyLogWriter: %v", err)
+	}
+}
@@ -110,6 +110,9 @@ type Conn struct {
 	ableToDetectNMinusOneSplitting   bool
 	readOneAppDataRecord             bool
 	nMinusOneRecordSplittingDetected bool
+	curveID                          CurveID         // group of the ECDHE key exchange, server side only
+	dhGroupBits                      int             // size of the DHE prime, server side only
+	signatureScheme                  SignatureScheme // scheme of the server's handshake signature, server side only
 }
 
 // Access to net.Conn methods.
@@ -162,6 +165,8 @@ type halfConn struct {
 	nextCipher interface{} // next encryption state
 	nextMac    macFunction // next MAC algorithm
 
+	trafficSecret []byte // current TLS 1.3 traffic secret
+
 	// used to save allocating a new buffer for each MAC.
 	inDigestBuf, outDigestBuf []byte
 }
@@ -195,6 +200,19 @@ func (hc *halfConn) changeCipherSpec() error {
 	return nil
 }
 
+// setTrafficSecret switches this direction to the TLS 1.3 AEAD keys derived
+// from secret. Unlike changeCipherSpec, it takes effect immediately.
+func (hc *halfConn) setTrafficSecret(suite *cipherSuiteTLS13, secret []byte) {
+	hc.trafficSecret = secret
+	key, iv := suite.trafficKey(secret)
+	hc.version = VersionTLS13
+	hc.cipher = suite.aead(key, iv)
+	hc.mac = nil
+	for i := range hc.seq {
+		hc.seq[i] = 0
+	}
+}
+
 // incSeq increments the sequence number.
 func (hc *halfConn) incSeq() {
 	for i := 7; i >= 0; i-- {
@@ -308,16 +326,38 @@ func (hc *halfConn) decrypt(b *block) (ok bool, prefixLen int, alertValue alert)
 				nonce = hc.seq[:]
 			}
 
-			copy(hc.additionalData[:], hc.seq[:])
-			copy(hc.additionalData[8:], b.data[:3])
-			n := len(payload) - c.Overhead()
-			hc.additionalData[11] = byte(n >> 8)
-			hc.additionalData[12] = byte(n)
+			var additionalData []byte
+			if hc.version == VersionTLS13 {
+				// TLS 1.3 authenticates the record header as it
+				// appears on the wire. See RFC 8446, Section 5.2.
+				additionalData = b.data[:recordHeaderLen]
+			} else {
+				copy(hc.additionalData[:], hc.seq[:])
+				copy(hc.additionalData[8:], b.data[:3])
+				n := len(payload) - c.Overhead()
+				hc.additionalData[11] = byte(n >> 8)
+				hc.additionalData[12] = byte(n)
+				additionalData = hc.additionalData[:]
+			}
 			var err error
-			payload, err = c.Open(payload[:0], nonce, payload, hc.additionalData[:])
+			payload, err = c.Open(payload[:0], nonce, payload, additionalData)
 			if err != nil {
 				return false, 0, alertBadRecordMAC
 			}
+			if hc.version == VersionTLS13 {
+				// Strip the zero padding and recover the real
+				// content type, which is written back into the
+				// record header for readRecord.
+				i := len(payload) - 1
+				for i >= 0 && payload[i] == 0 {
+					i--
+				}
+				if i < 0 {
+					return false, 0, alertUnexpectedMessage
+				}
+				b.data[0] = payload[i]
+				payload = payload[:i]
+			}
 			b.resize(recordHeaderLen + explicitIVLen + len(payload))
 		case cbcMode:
 			blockSize := c.BlockSize()
@@ -423,12 +463,23 @@ func (hc *halfConn) encrypt(b *block, explicitIVLen int) (bool, alert) {
 			payload := b.data[recordHeaderLen+explicitIVLen:]
 			payload = payload[:payloadLen]
 
-			copy(hc.additionalData[:], hc.seq[:])
-			copy(hc.additionalData[8:], b.data[:3])
-			hc.additionalData[11] = byte(payloadLen >> 8)
-			hc.additionalData[12] = byte(payloadLen)
+			var additionalData []byte
+			if hc.version == VersionTLS13 {
+				// The record header, including the final
+				// length, is the additional data in TLS 1.3.
+				n := payloadLen + c.Overhead()
+				b.data[3] = byte(n >> 8)
+				b.data[4] = byte(n)
+				additionalData = b.data[:recordHeaderLen]
+			} else {
+				copy(hc.additionalData[:], hc.seq[:])
+				copy(hc.additionalData[8:], b.data[:3])
+				hc.additionalData[11] = byte(payloadLen >> 8)
+				hc.additionalData[12] = byte(payloadLen)
+				additionalData = hc.additionalData[:]
+			}
 
-			c.Seal(payload[:0], nonce, payload, hc.additionalData[:])
+			c.Seal(payload[:0], nonce, payload, additionalData)
 		case cbcMode:
 			blockSize := c.BlockSize()
 			if explicitIVLen > 0 {
@@ -623,7 +674,8 @@ Again:
 
 	vers := uint16(b.data[1])<<8 | uint16(b.data[2])
 	n := int(b.data[3])<<8 | int(b.data[4])
-	if c.haveVers && vers != c.vers {
+	// TLS 1.3 records carry a fixed legacy version which is not checked.
+	if c.haveVers && c.vers != VersionTLS13 && vers != c.vers {
 		c.sendAlert(alertProtocolVersion)
 		msg := fmt.Sprintf("received record with version %x when expecting version %x", vers, c.vers)
 		return c.in.setErrorLocked(c.newRecordHeaderError(msg))
@@ -655,12 +707,33 @@ Again:
 
 	// Process message.
 	b, c.rawInput = c.in.splitBlock(b, recordHeaderLen+n)
+
+	// TLS 1.3 middlebox compatibility mode sends an unencrypted
+	// ChangeCipherSpec record during the handshake that must be ignored.
+	// See RFC 8446, Section 5.
+	if c.vers == VersionTLS13 && typ == recordTypeChangeCipherSpec {
+		if c.handshakeComplete || n != 1 || b.data[recordHeaderLen] != 1 {
+			c.in.freeBlock(b)
+			return c.in.setErrorLocked(c.sendAlert(alertUnexpectedMessage))
+		}
+		c.in.freeBlock(b)
+		goto Again
+	}
+
 	ok, off, alertValue := c.in.decrypt(b)
 	if !ok {
 		c.in.freeBlock(b)
 		return c.in.setErrorLocked(c.sendAlert(alertValue))
 	}
 	b.off = off
+	if c.in.version == VersionTLS13 && c.in.cipher != nil {
+		if typ != recordTypeApplicationData {
+			c.in.freeBlock(b)
+			return c.in.setErrorLocked(c.sendAlert(alertUnexpectedMessage))
+		}
+		// decrypt has replaced the outer type with the inner one.
+		typ = recordType(b.data[0])
+	}
 	data := b.data[b.off:]
 	if len(data) > maxPlaintext {
 		err := c.sendAlert(alertRecordOverflow)
@@ -742,7 +815,8 @@ Again:
 
 	case recordTypeHandshake:
 		// TODO(rsc): Should at least pick off connection close.
-		if typ != want && !(c.isClient && c.config.Renegotiation != RenegotiateNever) {
+		// TLS 1.3 allows post-handshake messages such as KeyUpdate.
+		if typ != want && c.vers != VersionTLS13 && !(c.isClient && c.config.Renegotiation != RenegotiateNever) {
 			return c.in.setErrorLocked(c.sendAlert(alertNoRenegotiation))
 		}
 		c.hand.Write(data)
@@ -836,6 +910,9 @@ func (c *Conn) maxPayloadSizeForWrite(typ recordType, explicitIVLen int) int {
 			payloadBytes -= macSize
 		case cipher.AEAD:
 			payloadBytes -= ciph.Overhead()
+			if c.out.version == VersionTLS13 {
+				payloadBytes-- // encrypted content type
+			}
 		case cbcMode:
 			blockSize := ciph.BlockSize()
 			// The payload must fit in a multiple of blockSize, with
@@ -923,6 +1000,9 @@ func (c *Conn) writeRecordLocked(typ recordType, data []byte) (int, error) {
 		if maxPayload := c.maxPayloadSizeForWrite(typ, explicitIVLen); m > maxPayload {
 			m = maxPayload
 		}
+		// Encrypted TLS 1.3 records hide their real type after the
+		// payload and are all sent as application data.
+		innerType := c.out.version == VersionTLS13 && c.out.cipher != nil
 		b.resize(recordHeaderLen + explicitIVLen + m)
 		b.data[0] = byte(typ)
 		vers := c.vers
@@ -930,6 +1010,9 @@ func (c *Conn) writeRecordLocked(typ recordType, data []byte) (int, error) {
 			// Some TLS servers fail if the record version is
 			// greater than TLS 1.0 for the initial ClientHello.
 			vers = VersionTLS10
+		} else if vers == VersionTLS13 {
+			// TLS 1.3 froze the record layer version at TLS 1.2.
+			vers = VersionTLS12
 		}
 		b.data[1] = byte(vers >> 8)
 		b.data[2] = byte(vers)
@@ -946,6 +1029,11 @@ func (c *Conn) writeRecordLocked(typ recordType, data []byte) (int, error) {
 			}
 		}
 		copy(b.data[recordHeaderLen+explicitIVLen:], data)
+		if innerType {
+			b.data[0] = byte(recordTypeApplicationData)
+			b.resize(len(b.data) + 1)
+			b.data[len(b.data)-1] = byte(typ)
+		}
 		c.out.encrypt(b, explicitIVLen)
 		if _, err := c.write(b.data); err != nil {
 			return n, err
@@ -954,7 +1042,8 @@ func (c *Conn) writeRecordLocked(typ recordType, data []byte) (int, error) {
 		data = data[m:]
 	}
 
-	if typ == recordTypeChangeCipherSpec {
+	// The TLS 1.3 ChangeCipherSpec is only for middlebox compatibility.
+	if typ == recordTypeChangeCipherSpec && c.vers != VersionTLS13 {
 		if err := c.out.changeCipherSpec(); err != nil {
 			return n, c.sendAlertLocked(err.(alert))
 		}
@@ -1011,8 +1100,14 @@ func (c *Conn) readHandshake() (interface{}, error) {
 		m = new(serverHelloMsg)
 	case typeNewSessionTicket:
 		m = new(newSessionTicketMsg)
+	case typeEncryptedExtensions:
+		m = new(encryptedExtensionsMsg)
 	case typeCertificate:
-		m = new(certificateMsg)
+		if c.vers == VersionTLS13 {
+			m = new(certificateMsgTLS13)
+		} else {
+			m = new(certificateMsg)
+		}
 	case typeCertificateRequest:
 		m = &certificateRequestMsg{
 			hasSignatureAndHash: c.vers >= VersionTLS12,
@@ -1033,6 +1128,8 @@ func (c *Conn) readHandshake() (interface{}, error) {
 		m = new(nextProtoMsg)
 	case typeFinished:
 		m = new(finishedMsg)
+	case typeKeyUpdate:
+		m = new(keyUpdateMsg)
 	default:
 		return nil, c.in.setErrorLocked(c.sendAlert(alertUnexpectedMessage))
 	}
@@ -1152,6 +1249,46 @@ func (c *Conn) handleRenegotiation() error {
 	return c.handshakeErr
 }
 
+// handlePostHandshakeMessage processes a TLS 1.3 post-handshake message. The
+// server never requests client certificates and clients don't send session
+// tickets, so KeyUpdate is the only message expected here.
+// c.in.Mutex <= L
+func (c *Conn) handlePostHandshakeMessage() error {
+	msg, err := c.readHandshake()
+	if err != nil {
+		return err
+	}
+
+	keyUpdate, ok := msg.(*keyUpdateMsg)
+	if !ok {
+		c.sendAlert(alertUnexpectedMessage)
+		return unexpectedMessageError(keyUpdate, msg)
+	}
+
+	suite := cipherSuiteTLS13ByID(c.cipherSuite)
+	if suite == nil {
+		return c.in.setErrorLocked(c.sendAlert(alertInternalError))
+	}
+
+	newSecret := suite.nextTrafficSecret(c.in.trafficSecret)
+	c.in.setTrafficSecret(suite, newSecret)
+
+	if keyUpdate.updateRequested {
+		c.out.Lock()
+		defer c.out.Unlock()
+
+		msg := &keyUpdateMsg{}
+		if _, err := c.writeRecordLocked(recordTypeHandshake, msg.marshal()); err != nil {
+			return c.out.setErrorLocked(err)
+		}
+
+		newSecret := suite.nextTrafficSecret(c.out.trafficSecret)
+		c.out.setTrafficSecret(suite, newSecret)
+	}
+
+	return nil
+}
+
 // Read can be made to time out and return a net.Error with Timeout() == true
 // after a fixed time limit; see SetDeadline and SetReadDeadline.
 func (c *Conn) Read(b []byte) (n int, err error) {
@@ -1177,9 +1314,14 @@ func (c *Conn) Read(b []byte) (n int, err error) {
 				return 0, err
 			}
 			if c.hand.Len() > 0 {
-				// We received handshake bytes, indicating the
-				// start of a renegotiation.
-				if err := c.handleRenegotiation(); err != nil {
+				// We received handshake bytes, indicating either
+				// a TLS 1.3 post-handshake message or the start
+				// of a renegotiation.
+				if c.vers == VersionTLS13 {
+					if err := c.handlePostHandshakeMessage(); err != nil {
+						return 0, err
+					}
+				} else if err := c.handleRenegotiation(); err != nil {
 					return 0, err
 				}
 			}
@@ -1384,30 +1526,126 @@ func (c *Conn) ConnectionState() ConnectionState {
 		state.DidResume = c.didResume
 		state.NegotiatedProtocolIsMutual = !c.clientProtocolFallback
 		state.CipherSuite = c.cipherSuite
+		state.CurveID = c.curveID
+		state.DHGroupBits = c.dhGroupBits
+		state.SignatureScheme = c.signatureScheme
 		state.PeerCertificates = c.peerCertificates
 		state.VerifiedChains = c.verifiedChains
 		state.SignedCertificateTimestamps = c.scts
 		state.OCSPResponse = c.ocspResponse
-		if !c.didResume {
+		// tls-unique is not defined for TLS 1.3. See RFC 8446, Section C.5.
+		if !c.didResume && c.vers != VersionTLS13 {
 			if c.clientFinishedIsFirst {
 				state.TLSUnique = c.clientFinished[:]
 			} else {
 				state.TLSUnique = c.serverFinished[:]
 			}
 		}
-		state.ClientCipherSuites = make([]uint16, len(c.clientHello.cipherSuites))
-		copy(state.ClientCipherSuites, c.clientHello.cipherSuites)
-		state.CompressionMethods = make([]uint8, len(c.clientHello.compressionMethods))
-		copy(state.CompressionMethods, c.clientHello.compressionMethods)
+		state.setClientHello(c.clientHello)
 		state.AbleToDetectNMinusOneSplitting = c.ableToDetectNMinusOneSplitting
 		state.NMinusOneRecordSplittingDetected = c.nMinusOneRecordSplittingDetected
-		state.SessionTicketsSupported = c.clientHello.ticketSupported
-		state.SupportedVersions = c.clientHello.supportedVersions
 	}
 
 	return state
 }
 
+// setClientHello sets the fields of state that describe the client's
+// ClientHello. Added for howsmyssl's use.
+func (state *ConnectionState) setClientHello(hello *clientHelloMsg) {
+	state.ClientCipherSuites = make([]uint16, len(hello.cipherSuites))
+	copy(state.ClientCipherSuites, hello.cipherSuites)
+	state.CompressionMethods = make([]uint8, len(hello.compressionMethods))
+	copy(state.CompressionMethods, hello.compressionMethods)
+	// TLS 1.3 clients signal resumption support with
+	// psk_key_exchange_modes rather than the session_ticket
+	// extension.
+	state.SessionTicketsSupported = hello.ticketSupported || len(hello.pskModes) > 0
+	state.SupportedVersions = hello.supportedVersions
+	state.ClientHello = make([]byte, len(hello.raw))
+	copy(state.ClientHello, hello.raw)
+	state.ClientHelloExtensions = make([]ClientHelloExtension, len(hello.extensions))
+	for i, ext := range hello.extensions {
+		state.ClientHelloExtensions[i] = ClientHelloExtension{
+			Type: ext.Type,
+			Data: append([]byte(nil), ext.Data...),
+		}
+	}
+	state.ClientVersion = hello.vers
+	state.ClientCurves = append([]CurveID(nil), hello.supportedCurves...)
+	state.ClientPointFormats = append([]uint8(nil), hello.supportedPoints...)
+	state.ClientALPNProtocols = append([]string(nil), hello.alpnProtocols...)
+	state.ClientSignatureAlgorithms = append([]SignatureScheme(nil), hello.supportedSignatureAlgorithms...)
+	state.ClientSignatureAlgorithmsCert = append([]SignatureScheme(nil), hello.supportedSignatureAlgorithmsCert...)
+	state.ClientKeyShareGroups = make([]CurveID, len(hello.keyShares))
+	for i, ks := range hello.keyShares {
+		state.ClientKeyShareGroups[i] = ks.group
+	}
+	state.ClientExtendedMasterSecret = hello.extendedMasterSecret
+	state.ClientEncryptThenMAC = hello.encryptThenMAC
+	state.ClientRenegotiationInfo = hello.renegotiationInfo
+	state.ClientRenegotiationSCSV = hello.renegotiationSCSV
+	state.ClientFallbackSCSV = hello.fallbackSCSV
+	if hello.ech != nil {
+		ech := *hello.ech
+		state.ClientEncryptedClientHello = &ech
+	}
+}
+
+// ReadClientHello reads the records carrying a client's first ClientHello
+// from r, without answering it. It returns every byte it read, so that they
+// can be replayed into another TLS implementation to finish the handshake,
+// along with the ClientHello fields of a ConnectionState. Added for
+// howsmyssl's use.
+func ReadClientHello(r io.Reader) ([]byte, ConnectionState, error) {
+	var state ConnectionState
+	var raw, msg []byte
+	n := -1
+	for n == -1 || len(msg) < 4+n {
+		hdr := make([]byte, recordHeaderLen)
+		m, err := io.ReadFull(r, hdr)
+		raw = append(raw, hdr[:m]...)
+		if err != nil {
+			return raw, state, err
+		}
+		if recordType(hdr[0]) != recordTypeHandshake {
+			err := RecordHeaderError{Msg: "first record does not look like a TLS handshake"}
+			copy(err.RecordHeader[:], hdr)
+			return raw, state, err
+		}
+		recLen := int(hdr[3])<<8 | int(hdr[4])
+		if recLen == 0 || recLen > maxPlaintext {
+			err := RecordHeaderError{Msg: fmt.Sprintf("oversized record received with length %d", recLen)}
+			copy(err.RecordHeader[:], hdr)
+			return raw, state, err
+		}
+		fragment := make([]byte, recLen)
+		m, err = io.ReadFull(r, fragment)
+		raw = append(raw, fragment[:m]...)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return raw, state, err
+		}
+		msg = append(msg, fragment...)
+		if n == -1 && len(msg) >= 4 {
+			if msg[0] != typeClientHello {
+				return raw, state, fmt.Errorf("tls: first handshake message has type %d, not ClientHello", msg[0])
+			}
+			n = int(msg[1])<<16 | int(msg[2])<<8 | int(msg[3])
+			if n > maxHandshake {
+				return raw, state, fmt.Errorf("tls: handshake message of length %d bytes exceeds maximum of %d bytes", n, maxHandshake)
+			}
+		}
+	}
+	hello := new(clientHelloMsg)
+	if !hello.unmarshal(msg[:4+n]) {
+		return raw, state, errors.New("tls: unable to parse ClientHello")
+	}
+	state.setClientHello(hello)
+	return raw, state, nil
+}
+
 // OCSPResponse returns the stapled OCSP response from the TLS server, if
 // any. (Only valid for client connections.)
 func (c *Conn) OCSPResponse() []byte {
@@ -48,7 +48,7 @@ func makeClientHello(config *Config) (*clientHelloMsg, error) {
 	}
 
 	hello := &clientHelloMsg{
-		vers:                         config.maxVersion(),
+		vers:                         config.clientMaxVersion(),
 		compressionMethods:           []uint8{compressionNone},
 		random:                       make([]byte, 32),
 		ocspStapling:                 true,
@@ -67,9 +67,10 @@ NextCipherSuite:
 	for _, suiteId := range possibleCipherSuites {
 		for _, suite := range cipherSuites {
 			// Explicitly whitelisting some meta cipher suites as okay to be
-			// used in TestSweet32 in howsmyssl's client config
+			// used in TestSweet32 and TestFallbackSCSVReported in howsmyssl's
+			// client config
 
-			if suiteId == 0x00FF || suiteId == 0x0A0A {
+			if suiteId == 0x00FF || suiteId == 0x0A0A || suiteId == TLS_FALLBACK_SCSV {
 				hello.cipherSuites = append(hello.cipherSuites, suiteId)
 				continue NextCipherSuite
 			}
@@ -149,7 +150,7 @@ func (c *Conn) clientHandshake() error {
 			}
 
 			versOk := candidateSession.vers >= c.config.minVersion() &&
-				candidateSession.vers <= c.config.maxVersion()
+				candidateSession.vers <= c.config.clientMaxVersion()
 			if versOk && cipherSuiteOk {
 				session = candidateSession
 			}
@@ -524,7 +525,7 @@ func (hs *clientHandshakeState) doFullHandshake() error {
 	}
 
 	hs.masterSecret = masterFromPreMasterSecret(c.vers, hs.suite, preMasterSecret, hs.hello.random, hs.serverHello.random)
-	if err := c.config.writeKeyLog(hs.hello.random, hs.masterSecret); err != nil {
+	if err := c.config.writeKeyLog(keyLogLabelTLS12, hs.hello.random, hs.masterSecret); err != nil {
 		c.sendAlert(alertInternalError)
 		return errors.New("tls: failed to write to key log: " + err.Error())
 	}
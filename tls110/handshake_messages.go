@@ -31,6 +31,23 @@ type clientHelloMsg struct {
 
 	// added for howsmyssl's early TLS 1.3 support
 	supportedVersions []uint16
+
+	// added for the TLS 1.3 server handshake
+	keyShares []keyShare
+	earlyData bool
+	pskModes  []uint8
+	cookie    []byte
+
+	// Added for howsmyssl's use. extensions holds every extension in the
+	// order the client sent it, including ones not parsed above.
+	extensions                       []ClientHelloExtension
+	extendedMasterSecret             bool
+	encryptThenMAC                   bool
+	renegotiationInfo                bool // the renegotiation_info extension was sent
+	renegotiationSCSV                bool // TLS_EMPTY_RENEGOTIATION_INFO_SCSV was sent
+	fallbackSCSV                     bool // TLS_FALLBACK_SCSV was sent
+	supportedSignatureAlgorithmsCert []SignatureScheme
+	ech                              *EncryptedClientHello
 }
 
 func (m *clientHelloMsg) equal(i interface{}) bool {
@@ -56,7 +73,20 @@ func (m *clientHelloMsg) equal(i interface{}) bool {
 		eqSignatureAlgorithms(m.supportedSignatureAlgorithms, m1.supportedSignatureAlgorithms) &&
 		m.secureRenegotiationSupported == m1.secureRenegotiationSupported &&
 		bytes.Equal(m.secureRenegotiation, m1.secureRenegotiation) &&
-		eqStrings(m.alpnProtocols, m1.alpnProtocols)
+		eqStrings(m.alpnProtocols, m1.alpnProtocols) &&
+		eqUint16s(m.supportedVersions, m1.supportedVersions) &&
+		eqKeyShares(m.keyShares, m1.keyShares) &&
+		m.earlyData == m1.earlyData &&
+		bytes.Equal(m.pskModes, m1.pskModes) &&
+		bytes.Equal(m.cookie, m1.cookie) &&
+		eqExtensions(m.extensions, m1.extensions) &&
+		m.extendedMasterSecret == m1.extendedMasterSecret &&
+		m.encryptThenMAC == m1.encryptThenMAC &&
+		m.renegotiationInfo == m1.renegotiationInfo &&
+		m.renegotiationSCSV == m1.renegotiationSCSV &&
+		m.fallbackSCSV == m1.fallbackSCSV &&
+		eqSignatureAlgorithms(m.supportedSignatureAlgorithmsCert, m1.supportedSignatureAlgorithmsCert) &&
+		eqECH(m.ech, m1.ech)
 }
 
 func (m *clientHelloMsg) marshal() []byte {
@@ -328,6 +358,10 @@ func (m *clientHelloMsg) unmarshal(data []byte) bool {
 		m.cipherSuites[i] = uint16(data[2+2*i])<<8 | uint16(data[3+2*i])
 		if m.cipherSuites[i] == scsvRenegotiation {
 			m.secureRenegotiationSupported = true
+			m.renegotiationSCSV = true
+		}
+		if m.cipherSuites[i] == TLS_FALLBACK_SCSV {
+			m.fallbackSCSV = true
 		}
 	}
 	data = data[2+cipherSuiteLen:]
@@ -350,6 +384,17 @@ func (m *clientHelloMsg) unmarshal(data []byte) bool {
 	m.supportedSignatureAlgorithms = nil
 	m.alpnProtocols = nil
 	m.scts = false
+	m.supportedVersions = nil
+	m.keyShares = nil
+	m.earlyData = false
+	m.pskModes = nil
+	m.cookie = nil
+	m.extensions = nil
+	m.extendedMasterSecret = false
+	m.encryptThenMAC = false
+	m.renegotiationInfo = false
+	m.supportedSignatureAlgorithmsCert = nil
+	m.ech = nil
 
 	if len(data) == 0 {
 		// ClientHello is optionally followed by extension data
@@ -375,6 +420,7 @@ func (m *clientHelloMsg) unmarshal(data []byte) bool {
 		if len(data) < length {
 			return false
 		}
+		m.extensions = append(m.extensions, ClientHelloExtension{Type: extension, Data: data[:length]})
 
 		switch extension {
 		case extensionServerName:
@@ -463,6 +509,22 @@ func (m *clientHelloMsg) unmarshal(data []byte) bool {
 				m.supportedSignatureAlgorithms[i] = SignatureScheme(d[0])<<8 | SignatureScheme(d[1])
 				d = d[2:]
 			}
+		case extensionSignatureAlgorithmsCert:
+			// https://tools.ietf.org/html/rfc8446#section-4.2.3
+			if length < 2 || length&1 != 0 {
+				return false
+			}
+			l := int(data[0])<<8 | int(data[1])
+			if l != length-2 {
+				return false
+			}
+			n := l / 2
+			d := data[2:]
+			m.supportedSignatureAlgorithmsCert = make([]SignatureScheme, n)
+			for i := range m.supportedSignatureAlgorithmsCert {
+				m.supportedSignatureAlgorithmsCert[i] = SignatureScheme(d[0])<<8 | SignatureScheme(d[1])
+				d = d[2:]
+			}
 		case extensionRenegotiationInfo:
 			if length == 0 {
 				return false
@@ -476,6 +538,52 @@ func (m *clientHelloMsg) unmarshal(data []byte) bool {
 
 			m.secureRenegotiation = d
 			m.secureRenegotiationSupported = true
+			m.renegotiationInfo = true
+		case extensionExtendedMasterSecret:
+			// https://tools.ietf.org/html/rfc7627#section-5.1
+			if length != 0 {
+				return false
+			}
+			m.extendedMasterSecret = true
+		case extensionEncryptThenMAC:
+			// https://tools.ietf.org/html/rfc7366#section-2
+			if length != 0 {
+				return false
+			}
+			m.encryptThenMAC = true
+		case extensionEncryptedClientHello:
+			// https://tools.ietf.org/html/draft-ietf-tls-esni-22#section-5
+			if length < 1 {
+				return false
+			}
+			ech := &EncryptedClientHello{Type: data[0]}
+			switch ech.Type {
+			case ECHClientHelloInner:
+				if length != 1 {
+					return false
+				}
+			case ECHClientHelloOuter:
+				d := data[1:length]
+				if len(d) < 7 {
+					return false
+				}
+				ech.KDF = uint16(d[0])<<8 | uint16(d[1])
+				ech.AEAD = uint16(d[2])<<8 | uint16(d[3])
+				ech.ConfigID = d[4]
+				ech.EncLength = int(d[5])<<8 | int(d[6])
+				d = d[7:]
+				if len(d) < ech.EncLength+2 {
+					return false
+				}
+				d = d[ech.EncLength:]
+				ech.PayloadLength = int(d[0])<<8 | int(d[1])
+				if ech.PayloadLength == 0 || len(d) != 2+ech.PayloadLength {
+					return false
+				}
+			default:
+				return false
+			}
+			m.ech = ech
 		case extensionALPN:
 			if length < 2 {
 				return false
@@ -515,6 +623,55 @@ func (m *clientHelloMsg) unmarshal(data []byte) bool {
 				m.supportedVersions = append(m.supportedVersions, v)
 				d = d[2:]
 			}
+		case extensionKeyShare:
+			// https://tools.ietf.org/html/rfc8446#section-4.2.8
+			if length < 2 {
+				return false
+			}
+			l := int(data[0])<<8 | int(data[1])
+			if length != l+2 {
+				return false
+			}
+			d := data[2:length]
+			for len(d) != 0 {
+				if len(d) < 4 {
+					return false
+				}
+				group := CurveID(d[0])<<8 | CurveID(d[1])
+				dataLen := int(d[2])<<8 | int(d[3])
+				d = d[4:]
+				if dataLen == 0 || len(d) < dataLen {
+					return false
+				}
+				m.keyShares = append(m.keyShares, keyShare{group: group, data: d[:dataLen]})
+				d = d[dataLen:]
+			}
+		case extensionEarlyData:
+			// https://tools.ietf.org/html/rfc8446#section-4.2.10
+			if length != 0 {
+				return false
+			}
+			m.earlyData = true
+		case extensionPSKModes:
+			// https://tools.ietf.org/html/rfc8446#section-4.2.9
+			if length < 1 {
+				return false
+			}
+			l := int(data[0])
+			if length != l+1 || l == 0 {
+				return false
+			}
+			m.pskModes = data[1:length]
+		case extensionCookie:
+			// https://tools.ietf.org/html/rfc8446#section-4.2.2
+			if length < 2 {
+				return false
+			}
+			l := int(data[0])<<8 | int(data[1])
+			if length != l+2 || l == 0 {
+				return false
+			}
+			m.cookie = data[2:length]
 		}
 		data = data[length:]
 	}
@@ -537,6 +694,12 @@ type serverHelloMsg struct {
 	secureRenegotiation          []byte
 	secureRenegotiationSupported bool
 	alpnProtocol                 string
+
+	// added for the TLS 1.3 server handshake
+	supportedVersion uint16
+	serverShare      keyShare
+	selectedGroup    CurveID // HelloRetryRequest only
+	cookie           []byte  // HelloRetryRequest only
 }
 
 func (m *serverHelloMsg) equal(i interface{}) bool {
@@ -566,7 +729,12 @@ func (m *serverHelloMsg) equal(i interface{}) bool {
 		m.ticketSupported == m1.ticketSupported &&
 		m.secureRenegotiationSupported == m1.secureRenegotiationSupported &&
 		bytes.Equal(m.secureRenegotiation, m1.secureRenegotiation) &&
-		m.alpnProtocol == m1.alpnProtocol
+		m.alpnProtocol == m1.alpnProtocol &&
+		m.supportedVersion == m1.supportedVersion &&
+		m.serverShare.group == m1.serverShare.group &&
+		bytes.Equal(m.serverShare.data, m1.serverShare.data) &&
+		m.selectedGroup == m1.selectedGroup &&
+		bytes.Equal(m.cookie, m1.cookie)
 }
 
 func (m *serverHelloMsg) marshal() []byte {
@@ -612,6 +780,22 @@ func (m *serverHelloMsg) marshal() []byte {
 		extensionsLength += 2 + sctLen
 		numExtensions++
 	}
+	if m.supportedVersion != 0 {
+		extensionsLength += 2
+		numExtensions++
+	}
+	if m.serverShare.group != 0 {
+		extensionsLength += 2 + 2 + len(m.serverShare.data)
+		numExtensions++
+	}
+	if m.selectedGroup != 0 {
+		extensionsLength += 2
+		numExtensions++
+	}
+	if len(m.cookie) > 0 {
+		extensionsLength += 2 + len(m.cookie)
+		numExtensions++
+	}
 
 	if numExtensions > 0 {
 		extensionsLength += 4 * numExtensions
@@ -707,6 +891,50 @@ func (m *serverHelloMsg) marshal() []byte {
 			z = z[len(sct)+2:]
 		}
 	}
+	if m.supportedVersion != 0 {
+		// https://tools.ietf.org/html/rfc8446#section-4.2.1
+		z[0] = byte(extensionSupportedVersions >> 8)
+		z[1] = byte(extensionSupportedVersions)
+		z[3] = 2
+		z[4] = byte(m.supportedVersion >> 8)
+		z[5] = byte(m.supportedVersion)
+		z = z[6:]
+	}
+	if m.serverShare.group != 0 {
+		// https://tools.ietf.org/html/rfc8446#section-4.2.8
+		z[0] = byte(extensionKeyShare >> 8)
+		z[1] = byte(extensionKeyShare)
+		l := 2 + 2 + len(m.serverShare.data)
+		z[2] = byte(l >> 8)
+		z[3] = byte(l)
+		z[4] = byte(m.serverShare.group >> 8)
+		z[5] = byte(m.serverShare.group)
+		z[6] = byte(len(m.serverShare.data) >> 8)
+		z[7] = byte(len(m.serverShare.data))
+		copy(z[8:], m.serverShare.data)
+		z = z[4+l:]
+	}
+	if m.selectedGroup != 0 {
+		// The HelloRetryRequest form of key_share only names the group.
+		z[0] = byte(extensionKeyShare >> 8)
+		z[1] = byte(extensionKeyShare)
+		z[3] = 2
+		z[4] = byte(m.selectedGroup >> 8)
+		z[5] = byte(m.selectedGroup)
+		z = z[6:]
+	}
+	if len(m.cookie) > 0 {
+		// https://tools.ietf.org/html/rfc8446#section-4.2.2
+		z[0] = byte(extensionCookie >> 8)
+		z[1] = byte(extensionCookie)
+		l := 2 + len(m.cookie)
+		z[2] = byte(l >> 8)
+		z[3] = byte(l)
+		z[4] = byte(len(m.cookie) >> 8)
+		z[5] = byte(len(m.cookie))
+		copy(z[6:], m.cookie)
+		z = z[4+l:]
+	}
 
 	m.raw = x
 
@@ -739,6 +967,10 @@ func (m *serverHelloMsg) unmarshal(data []byte) bool {
 	m.scts = nil
 	m.ticketSupported = false
 	m.alpnProtocol = ""
+	m.supportedVersion = 0
+	m.serverShare = keyShare{}
+	m.selectedGroup = 0
+	m.cookie = nil
 
 	if len(data) == 0 {
 		// ServerHello is optionally followed by extension data
@@ -846,6 +1078,35 @@ func (m *serverHelloMsg) unmarshal(data []byte) bool {
 				m.scts = append(m.scts, d[:sctLen])
 				d = d[sctLen:]
 			}
+		case extensionSupportedVersions:
+			if length != 2 {
+				return false
+			}
+			m.supportedVersion = uint16(data[0])<<8 | uint16(data[1])
+		case extensionKeyShare:
+			// A HelloRetryRequest carries only the selected group.
+			if length == 2 {
+				m.selectedGroup = CurveID(data[0])<<8 | CurveID(data[1])
+				break
+			}
+			if length < 4 {
+				return false
+			}
+			m.serverShare.group = CurveID(data[0])<<8 | CurveID(data[1])
+			l := int(data[2])<<8 | int(data[3])
+			if l == 0 || length != l+4 {
+				return false
+			}
+			m.serverShare.data = data[4:length]
+		case extensionCookie:
+			if length < 2 {
+				return false
+			}
+			l := int(data[0])<<8 | int(data[1])
+			if l == 0 || length != l+2 {
+				return false
+			}
+			m.cookie = data[2:length]
 		}
 		data = data[length:]
 	}
@@ -940,6 +1201,213 @@ func (m *certificateMsg) unmarshal(data []byte) bool {
 	return true
 }
 
+// certificateMsgTLS13 is the TLS 1.3 form of the Certificate message, in
+// which every entry carries its own extensions. See RFC 8446, Section 4.4.2.
+type certificateMsgTLS13 struct {
+	raw          []byte
+	certificate  Certificate
+	ocspStapling bool
+	scts         bool
+}
+
+func (m *certificateMsgTLS13) equal(i interface{}) bool {
+	m1, ok := i.(*certificateMsgTLS13)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(m.raw, m1.raw) &&
+		eqByteSlices(m.certificate.Certificate, m1.certificate.Certificate) &&
+		bytes.Equal(m.certificate.OCSPStaple, m1.certificate.OCSPStaple) &&
+		eqByteSlices(m.certificate.SignedCertificateTimestamps, m1.certificate.SignedCertificateTimestamps) &&
+		m.ocspStapling == m1.ocspStapling &&
+		m.scts == m1.scts
+}
+
+func (m *certificateMsgTLS13) marshal() (x []byte) {
+	if m.raw != nil {
+		return m.raw
+	}
+
+	// Only the leaf carries extensions.
+	var leafExt []byte
+	if m.ocspStapling && len(m.certificate.OCSPStaple) > 0 {
+		l := 4 + len(m.certificate.OCSPStaple)
+		ext := make([]byte, 4+l)
+		ext[0] = byte(extensionStatusRequest >> 8)
+		ext[1] = byte(extensionStatusRequest)
+		ext[2] = byte(l >> 8)
+		ext[3] = byte(l)
+		ext[4] = statusTypeOCSP
+		ext[5] = byte(len(m.certificate.OCSPStaple) >> 16)
+		ext[6] = byte(len(m.certificate.OCSPStaple) >> 8)
+		ext[7] = byte(len(m.certificate.OCSPStaple))
+		copy(ext[8:], m.certificate.OCSPStaple)
+		leafExt = append(leafExt, ext...)
+	}
+	if m.scts && len(m.certificate.SignedCertificateTimestamps) > 0 {
+		sctLen := 0
+		for _, sct := range m.certificate.SignedCertificateTimestamps {
+			sctLen += 2 + len(sct)
+		}
+		l := 2 + sctLen
+		ext := make([]byte, 4+l)
+		ext[0] = byte(extensionSCT >> 8)
+		ext[1] = byte(extensionSCT)
+		ext[2] = byte(l >> 8)
+		ext[3] = byte(l)
+		ext[4] = byte(sctLen >> 8)
+		ext[5] = byte(sctLen)
+		z := ext[6:]
+		for _, sct := range m.certificate.SignedCertificateTimestamps {
+			z[0] = byte(len(sct) >> 8)
+			z[1] = byte(len(sct))
+			copy(z[2:], sct)
+			z = z[2+len(sct):]
+		}
+		leafExt = append(leafExt, ext...)
+	}
+
+	certsLen := 0
+	for i, cert := range m.certificate.Certificate {
+		certsLen += 3 + len(cert) + 2
+		if i == 0 {
+			certsLen += len(leafExt)
+		}
+	}
+
+	// An empty certificate_request_context precedes the list.
+	length := 1 + 3 + certsLen
+	x = make([]byte, 4+length)
+	x[0] = typeCertificate
+	x[1] = uint8(length >> 16)
+	x[2] = uint8(length >> 8)
+	x[3] = uint8(length)
+	x[5] = uint8(certsLen >> 16)
+	x[6] = uint8(certsLen >> 8)
+	x[7] = uint8(certsLen)
+
+	y := x[8:]
+	for i, cert := range m.certificate.Certificate {
+		y[0] = uint8(len(cert) >> 16)
+		y[1] = uint8(len(cert) >> 8)
+		y[2] = uint8(len(cert))
+		copy(y[3:], cert)
+		y = y[3+len(cert):]
+
+		var ext []byte
+		if i == 0 {
+			ext = leafExt
+		}
+		y[0] = uint8(len(ext) >> 8)
+		y[1] = uint8(len(ext))
+		copy(y[2:], ext)
+		y = y[2+len(ext):]
+	}
+
+	m.raw = x
+	return
+}
+
+func (m *certificateMsgTLS13) unmarshal(data []byte) bool {
+	if len(data) < 5 {
+		return false
+	}
+
+	m.raw = data
+	m.certificate = Certificate{}
+	m.ocspStapling = false
+	m.scts = false
+
+	ctxLen := int(data[4])
+	data = data[5:]
+	if len(data) < ctxLen+3 {
+		return false
+	}
+	data = data[ctxLen:]
+	certsLen := int(data[0])<<16 | int(data[1])<<8 | int(data[2])
+	data = data[3:]
+	if len(data) != certsLen {
+		return false
+	}
+
+	for len(data) > 0 {
+		if len(data) < 3 {
+			return false
+		}
+		certLen := int(data[0])<<16 | int(data[1])<<8 | int(data[2])
+		if certLen == 0 || len(data) < 3+certLen+2 {
+			return false
+		}
+		m.certificate.Certificate = append(m.certificate.Certificate, data[3:3+certLen])
+		data = data[3+certLen:]
+
+		extLen := int(data[0])<<8 | int(data[1])
+		data = data[2:]
+		if len(data) < extLen {
+			return false
+		}
+		ext := data[:extLen]
+		data = data[extLen:]
+
+		// Extensions on anything but the leaf are ignored.
+		leaf := len(m.certificate.Certificate) == 1
+		for len(ext) > 0 {
+			if len(ext) < 4 {
+				return false
+			}
+			extension := uint16(ext[0])<<8 | uint16(ext[1])
+			length := int(ext[2])<<8 | int(ext[3])
+			ext = ext[4:]
+			if len(ext) < length {
+				return false
+			}
+			body := ext[:length]
+			ext = ext[length:]
+			if !leaf {
+				continue
+			}
+
+			switch extension {
+			case extensionStatusRequest:
+				if len(body) < 4 || body[0] != statusTypeOCSP {
+					return false
+				}
+				l := int(body[1])<<16 | int(body[2])<<8 | int(body[3])
+				if l == 0 || len(body) != 4+l {
+					return false
+				}
+				m.ocspStapling = true
+				m.certificate.OCSPStaple = body[4:]
+			case extensionSCT:
+				if len(body) < 2 {
+					return false
+				}
+				l := int(body[0])<<8 | int(body[1])
+				d := body[2:]
+				if l == 0 || len(d) != l {
+					return false
+				}
+				for len(d) > 0 {
+					if len(d) < 2 {
+						return false
+					}
+					sctLen := int(d[0])<<8 | int(d[1])
+					d = d[2:]
+					if sctLen == 0 || len(d) < sctLen {
+						return false
+					}
+					m.certificate.SignedCertificateTimestamps = append(m.certificate.SignedCertificateTimestamps, d[:sctLen])
+					d = d[sctLen:]
+				}
+				m.scts = true
+			}
+		}
+	}
+
+	return true
+}
+
 type serverKeyExchangeMsg struct {
 	raw []byte
 	key []byte
@@ -1510,6 +1978,156 @@ func (m *newSessionTicketMsg) unmarshal(data []byte) bool {
 	return true
 }
 
+// encryptedExtensionsMsg carries the TLS 1.3 server extensions that are
+// not needed to establish the handshake keys. See RFC 8446, Section 4.3.1.
+type encryptedExtensionsMsg struct {
+	raw          []byte
+	alpnProtocol string
+}
+
+func (m *encryptedExtensionsMsg) equal(i interface{}) bool {
+	m1, ok := i.(*encryptedExtensionsMsg)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(m.raw, m1.raw) &&
+		m.alpnProtocol == m1.alpnProtocol
+}
+
+func (m *encryptedExtensionsMsg) marshal() []byte {
+	if m.raw != nil {
+		return m.raw
+	}
+
+	extensionsLength := 0
+	if alpnLen := len(m.alpnProtocol); alpnLen > 0 {
+		extensionsLength += 4 + 2 + 1 + alpnLen
+	}
+
+	length := 2 + extensionsLength
+	x := make([]byte, 4+length)
+	x[0] = typeEncryptedExtensions
+	x[1] = uint8(length >> 16)
+	x[2] = uint8(length >> 8)
+	x[3] = uint8(length)
+	x[4] = uint8(extensionsLength >> 8)
+	x[5] = uint8(extensionsLength)
+
+	z := x[6:]
+	if alpnLen := len(m.alpnProtocol); alpnLen > 0 {
+		z[0] = byte(extensionALPN >> 8)
+		z[1] = byte(extensionALPN & 0xff)
+		l := 2 + 1 + alpnLen
+		z[2] = byte(l >> 8)
+		z[3] = byte(l)
+		l -= 2
+		z[4] = byte(l >> 8)
+		z[5] = byte(l)
+		l -= 1
+		z[6] = byte(l)
+		copy(z[7:], []byte(m.alpnProtocol))
+	}
+
+	m.raw = x
+	return x
+}
+
+func (m *encryptedExtensionsMsg) unmarshal(data []byte) bool {
+	if len(data) < 6 {
+		return false
+	}
+	m.raw = data
+	m.alpnProtocol = ""
+
+	extensionsLength := int(data[4])<<8 | int(data[5])
+	data = data[6:]
+	if len(data) != extensionsLength {
+		return false
+	}
+
+	for len(data) != 0 {
+		if len(data) < 4 {
+			return false
+		}
+		extension := uint16(data[0])<<8 | uint16(data[1])
+		length := int(data[2])<<8 | int(data[3])
+		data = data[4:]
+		if len(data) < length {
+			return false
+		}
+
+		switch extension {
+		case extensionALPN:
+			d := data[:length]
+			if len(d) < 3 {
+				return false
+			}
+			l := int(d[0])<<8 | int(d[1])
+			if l != len(d)-2 {
+				return false
+			}
+			d = d[2:]
+			l = int(d[0])
+			if l != len(d)-1 || l == 0 {
+				return false
+			}
+			m.alpnProtocol = string(d[1:])
+		}
+		data = data[length:]
+	}
+
+	return true
+}
+
+// keyUpdateMsg is the TLS 1.3 post-handshake KeyUpdate message. See RFC
+// 8446, Section 4.6.3.
+type keyUpdateMsg struct {
+	raw             []byte
+	updateRequested bool
+}
+
+func (m *keyUpdateMsg) equal(i interface{}) bool {
+	m1, ok := i.(*keyUpdateMsg)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(m.raw, m1.raw) &&
+		m.updateRequested == m1.updateRequested
+}
+
+func (m *keyUpdateMsg) marshal() []byte {
+	if m.raw != nil {
+		return m.raw
+	}
+
+	x := []byte{typeKeyUpdate, 0, 0, 1, 0}
+	if m.updateRequested {
+		x[4] = 1
+	}
+
+	m.raw = x
+	return x
+}
+
+func (m *keyUpdateMsg) unmarshal(data []byte) bool {
+	if len(data) != 5 {
+		return false
+	}
+	m.raw = data
+
+	switch data[4] {
+	case 0:
+		m.updateRequested = false
+	case 1:
+		m.updateRequested = true
+	default:
+		return false
+	}
+	return true
+}
+
 type helloRequestMsg struct {
 }
 
@@ -1580,3 +2198,34 @@ func eqSignatureAlgorithms(x, y []SignatureScheme) bool {
 	}
 	return true
 }
+
+func eqKeyShares(x, y []keyShare) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i].group != y[i].group || !bytes.Equal(x[i].data, y[i].data) {
+			return false
+		}
+	}
+	return true
+}
+
+func eqECH(x, y *EncryptedClientHello) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
+func eqExtensions(x, y []ClientHelloExtension) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i].Type != y[i].Type || !bytes.Equal(x[i].Data, y[i].Data) {
+			return false
+		}
+	}
+	return true
+}
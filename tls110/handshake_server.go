@@ -50,6 +50,22 @@ func (c *Conn) serverHandshake() error {
 		return err
 	}
 
+	if c.vers == VersionTLS13 {
+		hs13 := serverHandshakeStateTLS13{
+			c:               c,
+			clientHello:     hs.clientHello,
+			clientHelloInfo: hs.clientHelloInfo(),
+		}
+		if err := hs13.handshake(); err != nil {
+			return err
+		}
+		// Like the TLS 1.2 path, report the client's first ClientHello,
+		// even if a HelloRetryRequest made it send a second one.
+		c.clientHello = hs.clientHello
+		c.handshakeComplete = true
+		return nil
+	}
+
 	// For an overview of TLS handshaking, see https://tools.ietf.org/html/rfc5246#section-7.3
 	c.buffering = true
 	if isResume {
@@ -135,17 +151,26 @@ func (hs *serverHandshakeState) readClientHello() (isResume bool, err error) {
 		}
 	}
 
-	c.vers, ok = c.config.mutualVersion(hs.clientHello.vers)
-	if !ok {
-		c.sendAlert(alertProtocolVersion)
-		return false, fmt.Errorf("tls: client offered an unsupported, maximum protocol version of %x", hs.clientHello.vers)
+	if c.config.supportsTLS13() && offersVersion(hs.clientHello.supportedVersions, VersionTLS13) {
+		c.vers = VersionTLS13
+	} else {
+		c.vers, ok = c.config.mutualVersion(hs.clientHello.vers)
+		if !ok {
+			c.sendAlert(alertProtocolVersion)
+			return false, fmt.Errorf("tls: client offered an unsupported, maximum protocol version of %x", hs.clientHello.vers)
+		}
 	}
 	c.haveVers = true
+	if c.vers == VersionTLS13 {
+		// The rest of the ClientHello is processed by
+		// serverHandshakeStateTLS13.
+		return false, nil
+	}
 
 	hs.hello = new(serverHelloMsg)
 
 	supportedCurve := false
-	preferredCurves := c.config.curvePreferences()
+	preferredCurves := c.config.serverCurvePreferences()
 Curves:
 	for _, curve := range hs.clientHello.supportedCurves {
 		for _, supported := range preferredCurves {
@@ -187,6 +212,16 @@ Curves:
 		return false, err
 	}
 
+	// A server that could have negotiated TLS 1.3 signals the downgrade in
+	// the last eight bytes of its random. See RFC 8446, Section 4.1.3.
+	if maxVers := c.config.serverMaxVersion(); maxVers >= VersionTLS12 && c.vers < maxVers {
+		if c.vers == VersionTLS12 {
+			copy(hs.hello.random[24:], downgradeCanaryTLS12)
+		} else {
+			copy(hs.hello.random[24:], downgradeCanaryTLS11)
+		}
+	}
+
 	if len(hs.clientHello.secureRenegotiation) != 0 {
 		c.sendAlert(alertHandshakeFailure)
 		return false, errors.New("tls: initial handshake had non-empty renegotiation extension")
@@ -291,7 +326,7 @@ Curves:
 	for _, id := range hs.clientHello.cipherSuites {
 		if id == TLS_FALLBACK_SCSV {
 			// The client is doing a fallback connection.
-			if hs.clientHello.vers < c.config.maxVersion() {
+			if hs.clientHello.vers < c.config.serverMaxVersion() && !c.config.AllowInappropriateFallback {
 				c.sendAlert(alertInappropriateFallback)
 				return false, errors.New("tls: client using inappropriate protocol fallback")
 			}
@@ -515,8 +550,16 @@ func (hs *serverHandshakeState) doFullHandshake() error {
 		c.sendAlert(alertHandshakeFailure)
 		return err
 	}
+	switch ka := keyAgreement.(type) {
+	case *ecdheKeyAgreement:
+		c.curveID = ka.curveid
+		c.signatureScheme = ka.signatureAlgorithm
+	case *dheKeyAgreement:
+		c.dhGroupBits = ka.group.p.BitLen()
+		c.signatureScheme = ka.signatureAlgorithm
+	}
 	hs.masterSecret = masterFromPreMasterSecret(c.vers, hs.suite, preMasterSecret, hs.clientHello.random, hs.hello.random)
-	if err := c.config.writeKeyLog(hs.clientHello.random, hs.masterSecret); err != nil {
+	if err := c.config.writeKeyLog(keyLogLabelTLS12, hs.clientHello.random, hs.masterSecret); err != nil {
 		c.sendAlert(alertInternalError)
 		return err
 	}
@@ -813,6 +856,10 @@ func (hs *serverHandshakeState) setCipherSuite(id uint16, supportedCipherSuites
 				} else if !hs.rsaSignOk {
 					continue
 				}
+			} else if candidate.flags&suiteDHE != 0 {
+				if !hs.rsaSignOk {
+					continue
+				}
 			} else if !hs.rsaDecryptOk {
 				continue
 			}
@@ -826,6 +873,17 @@ func (hs *serverHandshakeState) setCipherSuite(id uint16, supportedCipherSuites
 	return false
 }
 
+// offersVersion reports whether vers is in the client's supported_versions
+// extension.
+func offersVersion(supportedVersions []uint16, vers uint16) bool {
+	for _, v := range supportedVersions {
+		if v == vers {
+			return true
+		}
+	}
+	return false
+}
+
 // suppVersArray is the backing array of ClientHelloInfo.SupportedVersions
 var suppVersArray = [...]uint16{VersionTLS12, VersionTLS11, VersionTLS10, VersionSSL30}
 
@@ -835,7 +893,9 @@ func (hs *serverHandshakeState) clientHelloInfo() *ClientHelloInfo {
 	}
 
 	var supportedVersions []uint16
-	if hs.clientHello.vers > VersionTLS12 {
+	if len(hs.clientHello.supportedVersions) > 0 {
+		supportedVersions = hs.clientHello.supportedVersions
+	} else if hs.clientHello.vers > VersionTLS12 {
 		supportedVersions = suppVersArray[:]
 	} else if hs.clientHello.vers >= VersionSSL30 {
 		supportedVersions = suppVersArray[VersionTLS12-hs.clientHello.vers:]
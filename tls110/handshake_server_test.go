@@ -0,0 +1,701 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tls
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	stdtls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed certificate for key.
+func testCertificate(t *testing.T, key interface{}) Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	var pub interface{}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		pub = &k.PublicKey
+	case *ecdsa.PrivateKey:
+		pub = &k.PublicKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func testRSACertificate(t *testing.T) Certificate {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testCertificate(t, key)
+}
+
+func testECDSACertificate(t *testing.T) Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testCertificate(t, key)
+}
+
+// localPipe returns both ends of a loopback TCP connection. Unlike net.Pipe,
+// writes are buffered, so both peers may write at once as TLS 1.3 requires.
+func localPipe(t *testing.T) (net.Conn, net.Conn) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	c, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := l.Accept()
+	if err != nil {
+		c.Close()
+		t.Fatal(err)
+	}
+	return c, s
+}
+
+// handshakeWithStdlib runs a handshake between a tls110 server and a
+// crypto/tls client, then echoes a message in each direction.
+func handshakeWithStdlib(t *testing.T, serverConfig *Config, clientConfig *stdtls.Config) ConnectionState {
+	t.Helper()
+	return handshakeWithClient(t, serverConfig, func(c net.Conn) clientConn {
+		return stdtls.Client(c, clientConfig)
+	})
+}
+
+// clientConn is the part of a client side *Conn, from this package or
+// crypto/tls, that handshakeWithClient uses.
+type clientConn interface {
+	net.Conn
+	Handshake() error
+}
+
+// handshakeWithClient is like handshakeWithStdlib but lets the caller pick
+// the client.
+func handshakeWithClient(t *testing.T, serverConfig *Config, newClient func(net.Conn) clientConn) ConnectionState {
+	t.Helper()
+	c, s := localPipe(t)
+	defer c.Close()
+	defer s.Close()
+
+	errc := make(chan error, 1)
+	go func() {
+		client := newClient(c)
+		if err := client.Handshake(); err != nil {
+			errc <- err
+			return
+		}
+		if _, err := client.Write([]byte("ping")); err != nil {
+			errc <- err
+			return
+		}
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(client, buf); err != nil {
+			errc <- err
+			return
+		}
+		if string(buf) != "pong" {
+			errc <- io.ErrUnexpectedEOF
+			return
+		}
+		errc <- client.Close()
+	}()
+
+	server := Server(s, serverConfig)
+	if err := server.Handshake(); err != nil {
+		t.Fatalf("server handshake: %v (client: %v)", err, <-errc)
+	}
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(server, buf); err != nil {
+		t.Fatalf("server read: %v", err)
+	}
+	if string(buf) != "ping" {
+		t.Fatalf("server read %q, want %q", buf, "ping")
+	}
+	if _, err := server.Write([]byte("pong")); err != nil {
+		t.Fatalf("server write: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	return server.ConnectionState()
+}
+
+func TestTLS13Handshake(t *testing.T) {
+	tests := []struct {
+		name string
+		cert Certificate
+	}{
+		{"RSA", testRSACertificate(t)},
+		{"ECDSA", testECDSACertificate(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig := &Config{
+				Certificates: []Certificate{tt.cert},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+			clientConfig := &stdtls.Config{
+				InsecureSkipVerify: true,
+				NextProtos:         []string{"http/1.1"},
+			}
+			st := handshakeWithStdlib(t, serverConfig, clientConfig)
+			if st.Version != VersionTLS13 {
+				t.Errorf("Version = %#04x, want %#04x", st.Version, VersionTLS13)
+			}
+			if cipherSuiteTLS13ByID(st.CipherSuite) == nil {
+				t.Errorf("CipherSuite = %#04x, want a TLS 1.3 suite", st.CipherSuite)
+			}
+			if st.NegotiatedProtocol != "http/1.1" {
+				t.Errorf("NegotiatedProtocol = %q, want %q", st.NegotiatedProtocol, "http/1.1")
+			}
+			if !offersVersion(st.SupportedVersions, VersionTLS13) {
+				t.Errorf("SupportedVersions = %#04x, want TLS 1.3 included", st.SupportedVersions)
+			}
+		})
+	}
+}
+
+func TestTLS13HelloRetryRequest(t *testing.T) {
+	// The client only sends key shares for its favourite groups, so a
+	// server that insists on P-384 has to ask for another ClientHello.
+	serverConfig := &Config{
+		Certificates:     []Certificate{testECDSACertificate(t)},
+		CurvePreferences: []CurveID{CurveP384},
+	}
+	clientConfig := &stdtls.Config{InsecureSkipVerify: true}
+	st := handshakeWithStdlib(t, serverConfig, clientConfig)
+	if st.Version != VersionTLS13 {
+		t.Errorf("Version = %#04x, want %#04x", st.Version, VersionTLS13)
+	}
+}
+
+func TestTLS13NotNegotiated(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverConfig *Config
+		clientConfig *stdtls.Config
+	}{
+		{
+			name:         "client max TLS 1.2",
+			serverConfig: &Config{},
+			clientConfig: &stdtls.Config{MaxVersion: stdtls.VersionTLS12},
+		},
+		{
+			name:         "server max TLS 1.2",
+			serverConfig: &Config{MaxVersion: VersionTLS12},
+			clientConfig: &stdtls.Config{},
+		},
+		{
+			name:         "server requests client certificates",
+			serverConfig: &Config{ClientAuth: RequestClientCert},
+			clientConfig: &stdtls.Config{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.serverConfig.Certificates = []Certificate{testECDSACertificate(t)}
+			tt.clientConfig.InsecureSkipVerify = true
+			st := handshakeWithStdlib(t, tt.serverConfig, tt.clientConfig)
+			if st.Version != VersionTLS12 {
+				t.Errorf("Version = %#04x, want %#04x", st.Version, VersionTLS12)
+			}
+		})
+	}
+}
+
+func TestTLS13KeyLog(t *testing.T) {
+	var keyLog bytes.Buffer
+	serverConfig := &Config{
+		Certificates: []Certificate{testECDSACertificate(t)},
+		KeyLogWriter: &keyLog,
+	}
+	handshakeWithStdlib(t, serverConfig, &stdtls.Config{InsecureSkipVerify: true})
+
+	var labels []string
+	for _, line := range strings.Split(strings.TrimSpace(keyLog.String()), "\n") {
+		labels = append(labels, strings.Fields(line)[0])
+	}
+	want := []string{
+		keyLogLabelClientHandshake,
+		keyLogLabelServerHandshake,
+		keyLogLabelClientTraffic,
+		keyLogLabelServerTraffic,
+	}
+	if strings.Join(labels, " ") != strings.Join(want, " ") {
+		t.Errorf("key log labels = %v, want %v", labels, want)
+	}
+}
+
+func TestServerCurvePreferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverCurves  []CurveID
+		clientCurves  []stdtls.CurveID
+		clientMaxVers uint16
+		want          CurveID
+	}{
+		{"TLS 1.2 default", nil, nil, VersionTLS12, X25519},
+		{"TLS 1.2 client prefers P-256", nil, []stdtls.CurveID{stdtls.CurveP256, stdtls.X25519}, VersionTLS12, X25519},
+		{"TLS 1.2 server prefers P-384", []CurveID{CurveP384, X25519}, nil, VersionTLS12, CurveP384},
+		{"TLS 1.2 server only P-256", []CurveID{CurveP256}, nil, VersionTLS12, CurveP256},
+		{"TLS 1.2 unsupported curve skipped", []CurveID{0x11ec, X25519}, nil, VersionTLS12, X25519},
+		{"TLS 1.3 default", nil, nil, VersionTLS13, X25519},
+		{"TLS 1.3 unsupported curve skipped", []CurveID{0x11ec, CurveP256}, nil, VersionTLS13, CurveP256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig := &Config{
+				Certificates:     []Certificate{testECDSACertificate(t)},
+				CurvePreferences: tt.serverCurves,
+			}
+			clientConfig := &stdtls.Config{
+				InsecureSkipVerify: true,
+				MaxVersion:         tt.clientMaxVers,
+				CurvePreferences:   tt.clientCurves,
+			}
+			st := handshakeWithStdlib(t, serverConfig, clientConfig)
+			if st.CurveID != tt.want {
+				t.Errorf("CurveID = %d, want %d", st.CurveID, tt.want)
+			}
+		})
+	}
+}
+
+func TestX25519LowOrderPoint(t *testing.T) {
+	params, err := generateECDHEParameters(rand.Reader, X25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ka := &ecdheKeyAgreement{version: VersionTLS12, curveid: X25519, params: params}
+	ckx := &clientKeyExchangeMsg{ciphertext: append([]byte{32}, make([]byte, 32)...)}
+	if _, err := ka.processClientKeyExchange(&Config{}, nil, ckx, VersionTLS12); err != errClientKeyExchange {
+		t.Errorf("processClientKeyExchange with an all-zero X25519 point: err = %v, want %v", err, errClientKeyExchange)
+	}
+}
+
+func TestSignatureScheme(t *testing.T) {
+	tests := []struct {
+		name   string
+		cert   func(*testing.T) Certificate
+		suites []uint16
+		vers   uint16
+		want   SignatureScheme
+	}{
+		{"TLS 1.3 ECDSA", testECDSACertificate, nil, VersionTLS13, ECDSAWithP256AndSHA256},
+		{"TLS 1.2 ECDHE-ECDSA", testECDSACertificate, nil, VersionTLS12, ECDSAWithP256AndSHA256},
+		{"TLS 1.2 ECDHE-RSA", testRSACertificate, []uint16{TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, VersionTLS12, PKCS1WithSHA256},
+		{"TLS 1.2 RSA key exchange", testRSACertificate, []uint16{TLS_RSA_WITH_AES_128_GCM_SHA256}, VersionTLS12, 0},
+		{"TLS 1.1 ECDHE-RSA", testRSACertificate, []uint16{TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA}, VersionTLS11, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig := &Config{
+				Certificates: []Certificate{tt.cert(t)},
+				CipherSuites: tt.suites,
+				MinVersion:   VersionTLS10,
+			}
+			clientConfig := &stdtls.Config{
+				InsecureSkipVerify: true,
+				CipherSuites:       tt.suites,
+				MinVersion:         tt.vers,
+				MaxVersion:         tt.vers,
+			}
+			st := handshakeWithStdlib(t, serverConfig, clientConfig)
+			if st.SignatureScheme != tt.want {
+				t.Errorf("SignatureScheme = %#04x, want %#04x", uint16(st.SignatureScheme), uint16(tt.want))
+			}
+		})
+	}
+}
+
+func TestDHE(t *testing.T) {
+	tests := []struct {
+		name  string
+		bits  int
+		suite uint16
+		vers  uint16
+		want  int
+	}{
+		{"default", 0, TLS_DHE_RSA_WITH_AES_128_GCM_SHA256, VersionTLS12, 2048},
+		{"512", 512, TLS_DHE_RSA_WITH_AES_128_GCM_SHA256, VersionTLS12, 512},
+		{"768", 768, TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256, VersionTLS12, 768},
+		{"1024", 1024, TLS_DHE_RSA_WITH_AES_256_GCM_SHA384, VersionTLS12, 1024},
+		{"TLS 1.0", 1024, TLS_DHE_RSA_WITH_AES_128_CBC_SHA, VersionTLS10, 1024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverConfig := &Config{
+				Certificates: []Certificate{testRSACertificate(t)},
+				CipherSuites: []uint16{tt.suite},
+				DHGroupBits:  tt.bits,
+			}
+			clientConfig := &Config{
+				InsecureSkipVerify: true,
+				CipherSuites:       []uint16{tt.suite},
+				MaxVersion:         tt.vers,
+			}
+			st := handshakeWithClient(t, serverConfig, func(c net.Conn) clientConn {
+				return Client(c, clientConfig)
+			})
+			if st.CipherSuite != tt.suite {
+				t.Errorf("CipherSuite = %#04x, want %#04x", st.CipherSuite, tt.suite)
+			}
+			if st.Version != tt.vers {
+				t.Errorf("Version = %#04x, want %#04x", st.Version, tt.vers)
+			}
+			if st.DHGroupBits != tt.want {
+				t.Errorf("DHGroupBits = %d, want %d", st.DHGroupBits, tt.want)
+			}
+			if st.CurveID != 0 {
+				t.Errorf("CurveID = %d, want 0 for DHE", st.CurveID)
+			}
+		})
+	}
+}
+
+func TestDHEGroups(t *testing.T) {
+	for bits, group := range dhGroups {
+		if group.p.BitLen() != bits {
+			t.Errorf("%d-bit group has a %d-bit prime", bits, group.p.BitLen())
+		}
+		q := new(big.Int).Rsh(group.p, 1)
+		if !group.p.ProbablyPrime(20) || !q.ProbablyPrime(20) {
+			t.Errorf("%d-bit group prime isn't a safe prime", bits)
+		}
+	}
+}
+
+func TestDHEInvalidClientPublic(t *testing.T) {
+	group := dhGroups[512]
+	x, _, err := generateDHKey(rand.Reader, group)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ka := &dheKeyAgreement{version: VersionTLS12, group: group, privateKey: x}
+	for _, y := range []*big.Int{big.NewInt(1), new(big.Int).Sub(group.p, big.NewInt(1)), group.p} {
+		ckx := &clientKeyExchangeMsg{ciphertext: appendDHParam(nil, y)}
+		if _, err := ka.processClientKeyExchange(&Config{}, nil, ckx, VersionTLS12); err != errClientKeyExchange {
+			t.Errorf("processClientKeyExchange with Yc = %x: err = %v, want %v", y, err, errClientKeyExchange)
+		}
+	}
+}
+
+func TestClientHelloExtensions(t *testing.T) {
+	serverConfig := &Config{Certificates: []Certificate{testECDSACertificate(t)}}
+	st := handshakeWithStdlib(t, serverConfig, &stdtls.Config{InsecureSkipVerify: true})
+
+	if len(st.ClientHello) < 4 || st.ClientHello[0] != typeClientHello {
+		t.Fatalf("ClientHello = %x, want a raw ClientHello message", st.ClientHello)
+	}
+	var m clientHelloMsg
+	if !m.unmarshal(st.ClientHello) {
+		t.Fatalf("ClientHello %x does not parse", st.ClientHello)
+	}
+
+	// Rebuild the extensions block from ConnectionState and check it matches
+	// the tail of the raw message byte for byte, which also checks order.
+	var exts []byte
+	seen := make(map[uint16]bool)
+	for _, ext := range st.ClientHelloExtensions {
+		exts = append(exts, byte(ext.Type>>8), byte(ext.Type), byte(len(ext.Data)>>8), byte(len(ext.Data)))
+		exts = append(exts, ext.Data...)
+		seen[ext.Type] = true
+	}
+	if !bytes.HasSuffix(st.ClientHello, exts) {
+		t.Errorf("ClientHelloExtensions don't match the end of the raw ClientHello")
+	}
+	for _, typ := range []uint16{extensionSupportedVersions, extensionKeyShare, extensionExtendedMasterSecret} {
+		if !seen[typ] {
+			t.Errorf("ClientHelloExtensions is missing extension %d", typ)
+		}
+	}
+	if !isSupportedSignatureAlgorithm(ECDSAWithP256AndSHA256, st.ClientSignatureAlgorithmsCert) {
+		t.Errorf("ClientSignatureAlgorithmsCert = %v, want ecdsa_secp256r1_sha256 included", st.ClientSignatureAlgorithmsCert)
+	}
+	if !st.ClientExtendedMasterSecret {
+		t.Errorf("ClientExtendedMasterSecret was false but the stdlib client sends it")
+	}
+	if !st.ClientRenegotiationInfo || st.ClientRenegotiationSCSV {
+		t.Errorf("ClientRenegotiationInfo, ClientRenegotiationSCSV = %v, %v; want true, false", st.ClientRenegotiationInfo, st.ClientRenegotiationSCSV)
+	}
+}
+
+func TestReadClientHello(t *testing.T) {
+	hello := &clientHelloMsg{
+		vers:               VersionTLS12,
+		random:             make([]byte, 32),
+		cipherSuites:       []uint16{TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_RSA_WITH_AES_128_CBC_SHA},
+		compressionMethods: []uint8{compressionNone},
+		supportedCurves:    []CurveID{X25519},
+	}
+	msg := hello.marshal()
+	// Split the message over two records, like big ClientHellos are.
+	var raw []byte
+	for _, frag := range [][]byte{msg[:10], msg[10:]} {
+		raw = append(raw, byte(recordTypeHandshake), 3, 1, byte(len(frag)>>8), byte(len(frag)))
+		raw = append(raw, frag...)
+	}
+	r := bytes.NewReader(append(raw, 0xff))
+	got, st, err := ReadClientHello(r)
+	if err != nil {
+		t.Fatalf("ReadClientHello: %s", err)
+	}
+	if !bytes.Equal(got, raw) || r.Len() != 1 {
+		t.Errorf("ReadClientHello read %x, leaving %d bytes; want %x, leaving 1", got, r.Len(), raw)
+	}
+	if !bytes.Equal(st.ClientHello, msg) {
+		t.Errorf("ClientHello = %x, want %x", st.ClientHello, msg)
+	}
+	if !reflect.DeepEqual(st.ClientCipherSuites, hello.cipherSuites) || !reflect.DeepEqual(st.ClientCurves, hello.supportedCurves) {
+		t.Errorf("ClientCipherSuites, ClientCurves = %v, %v; want %v, %v", st.ClientCipherSuites, st.ClientCurves, hello.cipherSuites, hello.supportedCurves)
+	}
+	if st.HandshakeComplete {
+		t.Errorf("HandshakeComplete was true")
+	}
+
+	bad := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated", raw[:len(raw)-1]},
+		{"application data", []byte{byte(recordTypeApplicationData), 3, 1, 0, 1, 0}},
+		{"empty record", []byte{byte(recordTypeHandshake), 3, 1, 0, 0}},
+		{"ServerHello", []byte{byte(recordTypeHandshake), 3, 1, 0, 4, typeServerHello, 0, 0, 0}},
+		{"garbage", []byte{byte(recordTypeHandshake), 3, 1, 0, 5, typeClientHello, 0, 0, 1, 0}},
+	}
+	for _, tt := range bad {
+		if _, _, err := ReadClientHello(bytes.NewReader(tt.data)); err == nil {
+			t.Errorf("%s: ReadClientHello succeeded", tt.name)
+		}
+	}
+}
+
+// appendExtension returns the marshaled hello with an extension appended,
+// for extensions clientHelloMsg can parse but not marshal.
+func appendExtension(hello *clientHelloMsg, typ uint16, data []byte) []byte {
+	msg := hello.marshal()
+	off := 4 + 2 + 32
+	off += 1 + int(msg[off])
+	off += 2 + (int(msg[off])<<8 | int(msg[off+1]))
+	off += 1 + int(msg[off])
+
+	msg = append(msg, byte(typ>>8), byte(typ), byte(len(data)>>8), byte(len(data)))
+	msg = append(msg, data...)
+	extLen := len(msg) - off - 2
+	msg[off], msg[off+1] = byte(extLen>>8), byte(extLen)
+	bodyLen := len(msg) - 4
+	msg[1], msg[2], msg[3] = byte(bodyLen>>16), byte(bodyLen>>8), byte(bodyLen)
+	return msg
+}
+
+func TestEncryptedClientHello(t *testing.T) {
+	newHello := func() *clientHelloMsg {
+		return &clientHelloMsg{
+			vers:               VersionTLS12,
+			random:             make([]byte, 32),
+			cipherSuites:       []uint16{TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			compressionMethods: []uint8{compressionNone},
+			supportedCurves:    []CurveID{X25519},
+		}
+	}
+	outer := []byte{ECHClientHelloOuter, 0, 1, 0, 1, 0x2a, 0, 32}
+	outer = append(outer, make([]byte, 32)...)
+	outer = append(outer, 0, 144)
+	outer = append(outer, make([]byte, 144)...)
+
+	tests := []struct {
+		name string
+		data []byte
+		want *EncryptedClientHello
+		ok   bool
+	}{
+		{"outer", outer, &EncryptedClientHello{Type: ECHClientHelloOuter, KDF: 1, AEAD: 1, ConfigID: 0x2a, EncLength: 32, PayloadLength: 144}, true},
+		{"inner", []byte{ECHClientHelloInner}, &EncryptedClientHello{Type: ECHClientHelloInner}, true},
+		{"empty", nil, nil, false},
+		{"inner with data", []byte{ECHClientHelloInner, 0}, nil, false},
+		{"truncated outer", outer[:len(outer)-1], nil, false},
+		{"empty payload", []byte{ECHClientHelloOuter, 0, 1, 0, 1, 0x2a, 0, 0, 0, 0}, nil, false},
+		{"unknown type", []byte{2}, nil, false},
+	}
+	for _, tt := range tests {
+		var m clientHelloMsg
+		ok := m.unmarshal(appendExtension(newHello(), extensionEncryptedClientHello, tt.data))
+		if ok != tt.ok {
+			t.Errorf("%s: unmarshal = %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if ok && !eqECH(m.ech, tt.want) {
+			t.Errorf("%s: ech = %+v, want %+v", tt.name, m.ech, tt.want)
+		}
+	}
+
+	var m clientHelloMsg
+	if !m.unmarshal(newHello().marshal()) || m.ech != nil {
+		t.Errorf("ech = %+v for a ClientHello without the extension, want nil", m.ech)
+	}
+}
+
+// rawClientHelloResponse sends a TLS 1.2 style ClientHello to a server using
+// config and returns the first record the server answers with.
+func rawClientHelloResponse(t *testing.T, config *Config, hello *clientHelloMsg) (recordType, []byte) {
+	t.Helper()
+	c, s := localPipe(t)
+	defer c.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer s.Close()
+		Server(s, config).Handshake()
+	}()
+
+	msg := hello.marshal()
+	record := []byte{byte(recordTypeHandshake), 3, 1, byte(len(msg) >> 8), byte(len(msg))}
+	if _, err := c.Write(append(record, msg...)); err != nil {
+		t.Fatal(err)
+	}
+
+	header := make([]byte, recordHeaderLen)
+	if _, err := io.ReadFull(c, header); err != nil {
+		t.Fatal(err)
+	}
+	payload := make([]byte, int(header[3])<<8|int(header[4]))
+	if _, err := io.ReadFull(c, payload); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	<-done
+	return recordType(header[0]), payload
+}
+
+func TestFallbackSCSV(t *testing.T) {
+	cert := testRSACertificate(t)
+	newHello := func(vers uint16, scsv bool) *clientHelloMsg {
+		hello := &clientHelloMsg{
+			vers:                         vers,
+			random:                       make([]byte, 32),
+			cipherSuites:                 []uint16{TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA},
+			compressionMethods:           []uint8{compressionNone},
+			supportedCurves:              []CurveID{CurveP256},
+			supportedPoints:              []uint8{pointFormatUncompressed},
+			supportedSignatureAlgorithms: supportedSignatureAlgorithms,
+		}
+		if scsv {
+			hello.cipherSuites = append(hello.cipherSuites, TLS_FALLBACK_SCSV)
+		}
+		return hello
+	}
+
+	tests := []struct {
+		name       string
+		config     *Config
+		hello      *clientHelloMsg
+		wantAlert  bool
+		wantRandom string // expected last eight bytes of the server random
+	}{
+		{
+			name:       "TLS 1.2 client without SCSV",
+			config:     &Config{},
+			hello:      newHello(VersionTLS12, false),
+			wantRandom: downgradeCanaryTLS12,
+		},
+		{
+			name:      "TLS 1.2 client with SCSV to TLS 1.3 server",
+			config:    &Config{},
+			hello:     newHello(VersionTLS12, true),
+			wantAlert: true,
+		},
+		{
+			name:   "TLS 1.2 client with SCSV to TLS 1.2 server",
+			config: &Config{MaxVersion: VersionTLS12},
+			hello:  newHello(VersionTLS12, true),
+		},
+		{
+			name:   "TLS 1.2 client with SCSV to server requesting client certificates",
+			config: &Config{ClientAuth: RequestClientCert},
+			hello:  newHello(VersionTLS12, true),
+		},
+		{
+			name:      "TLS 1.1 client with SCSV to TLS 1.2 server",
+			config:    &Config{MaxVersion: VersionTLS12},
+			hello:     newHello(VersionTLS11, true),
+			wantAlert: true,
+		},
+		{
+			name:       "TLS 1.1 client with SCSV to TLS 1.2 server allowing fallback",
+			config:     &Config{MaxVersion: VersionTLS12, AllowInappropriateFallback: true},
+			hello:      newHello(VersionTLS11, true),
+			wantRandom: downgradeCanaryTLS11,
+		},
+		{
+			name:       "TLS 1.1 client without SCSV to TLS 1.2 server",
+			config:     &Config{MaxVersion: VersionTLS12},
+			hello:      newHello(VersionTLS11, false),
+			wantRandom: downgradeCanaryTLS11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Certificates = []Certificate{cert}
+			typ, payload := rawClientHelloResponse(t, tt.config, tt.hello)
+			if tt.wantAlert {
+				if typ != recordTypeAlert || len(payload) != 2 || alert(payload[1]) != alertInappropriateFallback {
+					t.Fatalf("got record type %d payload %x, want an inappropriate_fallback alert", typ, payload)
+				}
+				return
+			}
+			if typ != recordTypeHandshake {
+				t.Fatalf("got record type %d payload %x, want a ServerHello", typ, payload)
+			}
+			n := 4 + (int(payload[1])<<16 | int(payload[2])<<8 | int(payload[3]))
+			serverHello := new(serverHelloMsg)
+			if len(payload) < n || !serverHello.unmarshal(payload[:n]) {
+				t.Fatalf("could not parse ServerHello %x", payload)
+			}
+			if serverHello.vers != tt.hello.vers {
+				t.Errorf("ServerHello version = %#04x, want %#04x", serverHello.vers, tt.hello.vers)
+			}
+			if got := string(serverHello.random[24:]); tt.wantRandom != "" && got != tt.wantRandom {
+				t.Errorf("server random ends in %q, want %q", got, tt.wantRandom)
+			} else if tt.wantRandom == "" && strings.HasPrefix(got, "DOWNGRD") {
+				t.Errorf("server random ends in unexpected downgrade canary %q", got)
+			}
+		})
+	}
+}
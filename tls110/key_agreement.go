@@ -9,6 +9,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/md5"
+	cryptorand "crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
 	"crypto/x509"
@@ -178,15 +179,27 @@ func curveForCurveID(id CurveID) (elliptic.Curve, bool) {
 
 }
 
+// SupportsCurve reports whether the server side can do ECDHE with the group
+// id. Added for howsmyssl's use.
+func SupportsCurve(id CurveID) bool {
+	_, ok := curveForCurveID(id)
+	return ok || id == X25519
+}
+
 // ecdheRSAKeyAgreement implements a TLS key agreement where the server
 // generates an ephemeral EC public/private key pair and signs it. The
 // pre-master secret is then calculated using ECDH. The signature may
 // either be ECDSA or RSA.
 type ecdheKeyAgreement struct {
-	version    uint16
-	sigType    uint8
-	privateKey []byte
-	curveid    CurveID
+	version uint16
+	sigType uint8
+	curveid CurveID
+
+	// params holds the server's ephemeral key.
+	params ecdheParameters
+	// signatureAlgorithm is the scheme the server signed its parameters
+	// with, in TLS 1.2 only.
+	signatureAlgorithm SignatureScheme
 
 	// publicKey is used to store the peer's public value when X25519 is
 	// being used.
@@ -197,7 +210,7 @@ type ecdheKeyAgreement struct {
 }
 
 func (ka *ecdheKeyAgreement) generateServerKeyExchange(config *Config, cert *Certificate, clientHello *clientHelloMsg, hello *serverHelloMsg) (*serverKeyExchangeMsg, error) {
-	preferredCurves := config.curvePreferences()
+	preferredCurves := config.serverCurvePreferences()
 
 NextCandidate:
 	for _, candidate := range preferredCurves {
@@ -213,31 +226,12 @@ NextCandidate:
 		return nil, errors.New("tls: no supported elliptic curves offered")
 	}
 
-	var ecdhePublic []byte
-
-	if ka.curveid == X25519 {
-		var scalar, public [32]byte
-		if _, err := io.ReadFull(config.rand(), scalar[:]); err != nil {
-			return nil, err
-		}
-
-		curve25519.ScalarBaseMult(&public, &scalar)
-		ka.privateKey = scalar[:]
-		ecdhePublic = public[:]
-	} else {
-		curve, ok := curveForCurveID(ka.curveid)
-		if !ok {
-			return nil, errors.New("tls: preferredCurves includes unsupported curve")
-		}
-
-		var x, y *big.Int
-		var err error
-		ka.privateKey, x, y, err = elliptic.GenerateKey(curve, config.rand())
-		if err != nil {
-			return nil, err
-		}
-		ecdhePublic = elliptic.Marshal(curve, x, y)
+	params, err := generateECDHEParameters(config.rand(), ka.curveid)
+	if err != nil {
+		return nil, err
 	}
+	ka.params = params
+	ecdhePublic := params.PublicKey()
 
 	// http://tools.ietf.org/html/rfc4492#section-5.4
 	serverECDHParams := make([]byte, 1+2+1+len(ecdhePublic))
@@ -250,11 +244,11 @@ NextCandidate:
 	var signatureAlgorithm SignatureScheme
 
 	if ka.version >= VersionTLS12 {
-		var err error
 		signatureAlgorithm, err = pickTLS12HashForSignature(ka.sigType, clientHello.supportedSignatureAlgorithms)
 		if err != nil {
 			return nil, err
 		}
+		ka.signatureAlgorithm = signatureAlgorithm
 	}
 
 	digest, hashFunc, err := hashForServerKeyExchange(ka.sigType, signatureAlgorithm, ka.version, clientHello.random, hello.random, serverECDHParams)
@@ -311,30 +305,12 @@ func (ka *ecdheKeyAgreement) processClientKeyExchange(config *Config, cert *Cert
 		return nil, errClientKeyExchange
 	}
 
-	if ka.curveid == X25519 {
-		if len(ckx.ciphertext) != 1+32 {
-			return nil, errClientKeyExchange
-		}
-
-		var theirPublic, sharedKey, scalar [32]byte
-		copy(theirPublic[:], ckx.ciphertext[1:])
-		copy(scalar[:], ka.privateKey)
-		curve25519.ScalarMult(&sharedKey, &scalar, &theirPublic)
-		return sharedKey[:], nil
-	}
-
-	curve, ok := curveForCurveID(ka.curveid)
-	if !ok {
-		panic("internal error")
-	}
-	x, y := elliptic.Unmarshal(curve, ckx.ciphertext[1:]) // Unmarshal also checks whether the given point is on the curve
-	if x == nil {
+	// SharedKey checks that the point is on the curve and, for X25519,
+	// rejects the low order points that give an all-zero secret.
+	preMasterSecret := ka.params.SharedKey(ckx.ciphertext[1:])
+	if preMasterSecret == nil {
 		return nil, errClientKeyExchange
 	}
-	x, _ = curve.ScalarMult(x, y, ka.privateKey)
-	preMasterSecret := make([]byte, (curve.Params().BitSize+7)>>3)
-	xBytes := x.Bytes()
-	copy(preMasterSecret[len(preMasterSecret)-len(xBytes):], xBytes)
 
 	return preMasterSecret, nil
 }
@@ -472,3 +448,230 @@ func (ka *ecdheKeyAgreement) generateClientKeyExchange(config *Config, clientHel
 
 	return preMasterSecret, ckx, nil
 }
+
+// dhGroup is a finite field Diffie-Hellman group. Added for howsmyssl's use.
+type dhGroup struct {
+	p, g *big.Int
+}
+
+func newDHGroup(p string) *dhGroup {
+	pi, ok := new(big.Int).SetString(p, 16)
+	if !ok {
+		panic("tls: bad DH prime " + p)
+	}
+	return &dhGroup{p: pi, g: big.NewInt(2)}
+}
+
+// dhGroups are the groups Config.DHGroupBits picks from. All of them are
+// safe primes with a generator of 2. The 512-bit prime was generated for
+// this package, since there's no standard one; the others are the Oakley
+// groups 1 and 2 from RFC 2409, Section 6 and ffdhe2048 from RFC 7919,
+// Appendix A.1.
+var dhGroups = map[int]*dhGroup{
+	512: newDHGroup("A286A02DCC0E1208481015D96E118F02A37E33D8A1DD55E3385B649126FC4351" +
+		"FBC297974D856E5ED1174F1C40BC0E1AAEEB7BB2E6FE32CB29B79DBA9BA33003"),
+	768: newDHGroup("FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74" +
+		"020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437" +
+		"4FE1356D6D51C245E485B576625E7EC6F44C42E9A63A3620FFFFFFFFFFFFFFFF"),
+	1024: newDHGroup("FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74" +
+		"020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F1437" +
+		"4FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED" +
+		"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE65381FFFFFFFFFFFFFFFF"),
+	2048: newDHGroup("FFFFFFFFFFFFFFFFADF85458A2BB4A9AAFDC5620273D3CF1D8B9C583CE2D3695" +
+		"A9E13641146433FBCC939DCE249B3EF97D2FE363630C75D8F681B202AEC4617A" +
+		"D3DF1ED5D5FD65612433F51F5F066ED0856365553DED1AF3B557135E7F57C935" +
+		"984F0C70E0E68B77E2A689DAF3EFE8721DF158A136ADE73530ACCA4F483A797A" +
+		"BC0AB182B324FB61D108A94BB2C8E3FBB96ADAB760D7F4681D4F42A3DE394DF4" +
+		"AE56EDE76372BB190B07A7C8EE0A6D709E02FCE1CDF7E2ECC03404CD28342F61" +
+		"9172FE9CE98583FF8E4F1232EEF28183C3FE3B1B4C6FAD733BB5FCBC2EC22005" +
+		"C58EF1837D1683B2C6F34A26C1B2EFFA886B423861285C97FFFFFFFFFFFFFFFF"),
+}
+
+// dheKeyAgreement implements a TLS key agreement where the server generates
+// an ephemeral finite field Diffie-Hellman key pair and signs it with RSA.
+// See RFC 5246, Section 7.4.3. Added for howsmyssl's use.
+type dheKeyAgreement struct {
+	version    uint16
+	group      *dhGroup
+	privateKey *big.Int
+	// signatureAlgorithm is the scheme the server signed its parameters
+	// with, in TLS 1.2 only.
+	signatureAlgorithm SignatureScheme
+	// theirPublic is used to store the server's public value on the client
+	// side.
+	theirPublic *big.Int
+}
+
+// generateDHKey returns a private exponent in [2, p-2] for group and the
+// matching public value.
+func generateDHKey(rand io.Reader, group *dhGroup) (*big.Int, *big.Int, error) {
+	x, err := cryptorand.Int(rand, new(big.Int).Sub(group.p, big.NewInt(3)))
+	if err != nil {
+		return nil, nil, err
+	}
+	x.Add(x, big.NewInt(2))
+	return x, new(big.Int).Exp(group.g, x, group.p), nil
+}
+
+// validDHPublic reports whether y is in [2, p-2], which rules out the
+// values that force the shared secret to 0, 1 or p-1.
+func validDHPublic(group *dhGroup, y *big.Int) bool {
+	return y.Cmp(big.NewInt(1)) > 0 && y.Cmp(new(big.Int).Sub(group.p, big.NewInt(1))) < 0
+}
+
+// appendDHParam appends x with a two byte length prefix.
+func appendDHParam(b []byte, x *big.Int) []byte {
+	xb := x.Bytes()
+	b = append(b, byte(len(xb)>>8), byte(len(xb)))
+	return append(b, xb...)
+}
+
+// readDHParam reads a value written by appendDHParam, returning it and the
+// rest of b.
+func readDHParam(b []byte) (*big.Int, []byte, bool) {
+	if len(b) < 2 {
+		return nil, nil, false
+	}
+	n := int(b[0])<<8 | int(b[1])
+	if n == 0 || len(b) < 2+n {
+		return nil, nil, false
+	}
+	return new(big.Int).SetBytes(b[2 : 2+n]), b[2+n:], true
+}
+
+func (ka *dheKeyAgreement) generateServerKeyExchange(config *Config, cert *Certificate, clientHello *clientHelloMsg, hello *serverHelloMsg) (*serverKeyExchangeMsg, error) {
+	bits := config.DHGroupBits
+	if bits == 0 {
+		bits = 2048
+	}
+	group, ok := dhGroups[bits]
+	if !ok {
+		return nil, errors.New("tls: unsupported DHGroupBits")
+	}
+	ka.group = group
+
+	x, y, err := generateDHKey(config.rand(), group)
+	if err != nil {
+		return nil, err
+	}
+	ka.privateKey = x
+
+	serverDHParams := appendDHParam(nil, group.p)
+	serverDHParams = appendDHParam(serverDHParams, group.g)
+	serverDHParams = appendDHParam(serverDHParams, y)
+
+	var signatureAlgorithm SignatureScheme
+	if ka.version >= VersionTLS12 {
+		signatureAlgorithm, err = pickTLS12HashForSignature(signatureRSA, clientHello.supportedSignatureAlgorithms)
+		if err != nil {
+			return nil, err
+		}
+		ka.signatureAlgorithm = signatureAlgorithm
+	}
+
+	digest, hashFunc, err := hashForServerKeyExchange(signatureRSA, signatureAlgorithm, ka.version, clientHello.random, hello.random, serverDHParams)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("tls: certificate private key does not implement crypto.Signer")
+	}
+	if _, ok := priv.Public().(*rsa.PublicKey); !ok {
+		return nil, errors.New("tls: DHE RSA requires a RSA server key")
+	}
+	sig, err := priv.Sign(config.rand(), digest, hashFunc)
+	if err != nil {
+		return nil, errors.New("tls: failed to sign DHE parameters: " + err.Error())
+	}
+
+	skx := new(serverKeyExchangeMsg)
+	skx.key = serverDHParams
+	if ka.version >= VersionTLS12 {
+		skx.key = append(skx.key, byte(signatureAlgorithm>>8), byte(signatureAlgorithm))
+	}
+	skx.key = append(skx.key, byte(len(sig)>>8), byte(len(sig)))
+	skx.key = append(skx.key, sig...)
+
+	return skx, nil
+}
+
+func (ka *dheKeyAgreement) processClientKeyExchange(config *Config, cert *Certificate, ckx *clientKeyExchangeMsg, version uint16) ([]byte, error) {
+	y, rest, ok := readDHParam(ckx.ciphertext)
+	if !ok || len(rest) != 0 || !validDHPublic(ka.group, y) {
+		return nil, errClientKeyExchange
+	}
+	// Leading zero bytes are stripped. See RFC 5246, Section 8.1.2.
+	return new(big.Int).Exp(y, ka.privateKey, ka.group.p).Bytes(), nil
+}
+
+func (ka *dheKeyAgreement) processServerKeyExchange(config *Config, clientHello *clientHelloMsg, serverHello *serverHelloMsg, cert *x509.Certificate, skx *serverKeyExchangeMsg) error {
+	p, rest, ok := readDHParam(skx.key)
+	if !ok {
+		return errServerKeyExchange
+	}
+	g, rest, ok := readDHParam(rest)
+	if !ok {
+		return errServerKeyExchange
+	}
+	y, rest, ok := readDHParam(rest)
+	if !ok {
+		return errServerKeyExchange
+	}
+	serverDHParams := skx.key[:len(skx.key)-len(rest)]
+	sig := rest
+
+	// Any group size is accepted, which makes the tls110 client useful for
+	// testing the weak group probes.
+	ka.group = &dhGroup{p: p, g: g}
+	if !validDHPublic(ka.group, y) {
+		return errServerKeyExchange
+	}
+	ka.theirPublic = y
+
+	var signatureAlgorithm SignatureScheme
+	if ka.version >= VersionTLS12 {
+		if len(sig) < 2 {
+			return errServerKeyExchange
+		}
+		signatureAlgorithm = SignatureScheme(sig[0])<<8 | SignatureScheme(sig[1])
+		if signatureFromSignatureScheme(signatureAlgorithm) != signatureRSA {
+			return errServerKeyExchange
+		}
+		sig = sig[2:]
+	}
+	if len(sig) < 2 {
+		return errServerKeyExchange
+	}
+	sigLen := int(sig[0])<<8 | int(sig[1])
+	if sigLen+2 != len(sig) {
+		return errServerKeyExchange
+	}
+	sig = sig[2:]
+
+	digest, hashFunc, err := hashForServerKeyExchange(signatureRSA, signatureAlgorithm, ka.version, clientHello.random, serverHello.random, serverDHParams)
+	if err != nil {
+		return err
+	}
+	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("tls: DHE RSA requires a RSA server public key")
+	}
+	return rsa.VerifyPKCS1v15(pubKey, hashFunc, digest, sig)
+}
+
+func (ka *dheKeyAgreement) generateClientKeyExchange(config *Config, clientHello *clientHelloMsg, cert *x509.Certificate) ([]byte, *clientKeyExchangeMsg, error) {
+	if ka.theirPublic == nil {
+		return nil, nil, errors.New("tls: missing ServerKeyExchange message")
+	}
+	x, y, err := generateDHKey(config.rand(), ka.group)
+	if err != nil {
+		return nil, nil, err
+	}
+	preMasterSecret := new(big.Int).Exp(ka.theirPublic, x, ka.group.p).Bytes()
+
+	ckx := new(clientKeyExchangeMsg)
+	ckx.ciphertext = appendDHParam(nil, y)
+	return preMasterSecret, ckx, nil
+}
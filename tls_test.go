@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	stdtls "crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"expvar"
@@ -32,7 +33,7 @@ func TestBEASTVuln(t *testing.T) {
 		if !st.NMinusOneRecordSplittingDetected {
 			t.Errorf("TLS 1.0, CBC suite, Conn: NMinusOneRecordSplittingDetected was false")
 		}
-		ci := pullClientInfo(c)
+		ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
 		if ci.BEASTVuln {
 			t.Errorf("TLS 1.0, CBC suite, ClientInfo: BEASTVuln should be false because Go mitigates the BEAST attack even on TLS 1.0")
 		}
@@ -53,7 +54,7 @@ func TestBEASTVuln(t *testing.T) {
 		if st.AbleToDetectNMinusOneSplitting {
 			t.Errorf("TLS 1.0, no CBC suites, Conn: AbleToDetectNMinusOneSplitting was true")
 		}
-		ci := pullClientInfo(c)
+		ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
 		if ci.BEASTVuln {
 			t.Errorf("TLS 1.0, no CBC suites, ClientInfo: BEASTVuln should be false because Go mitigates the BEAST attack even on TLS 1.0")
 		}
@@ -72,7 +73,7 @@ func TestBEASTVuln(t *testing.T) {
 		if st.AbleToDetectNMinusOneSplitting {
 			t.Errorf("TLS 1.2+, no CBC suites, Conn: AbleToDetectNMinusOneSplitting was true")
 		}
-		ci := pullClientInfo(c)
+		ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
 		if ci.BEASTVuln {
 			t.Errorf("TLS 1.2+, no CBC suites, ClientInfo: BEASTVuln should be false because Go mitigates the BEAST attack even on TLS 1.0")
 		}
@@ -83,18 +84,18 @@ func TestBEASTVuln(t *testing.T) {
 }
 
 // This is not to make sure that howsmyssl thinks the Go tls library is good,
-// but, instead, we assume the client is "Probably Okay" and look to see that we
+// but, instead, we assume the client is "not bad" and look to see that we
 // can handle that golden path.
-func TestGoDefaultIsOkay(t *testing.T) {
+func TestGoDefaultIsImprovable(t *testing.T) {
 	clientConf := &tls.Config{}
 	c := connect(t, clientConf)
-	ci := pullClientInfo(c)
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
 	t.Logf("%#v", ci)
 
-	if ci.Rating != okay {
-		t.Errorf("Go client rating: want %s, got %s", okay, ci.Rating)
+	if ci.Rating != improvable {
+		t.Errorf("Go client rating: want %s, got %s", improvable, ci.Rating)
 	}
-	if len(ci.GivenCipherSuites) == 0 {
+	if len(ci.SupportedCipherSuites) == 0 {
 		t.Errorf("no cipher suites given")
 	}
 	if ci.TLSCompressionSupported {
@@ -105,6 +106,296 @@ func TestGoDefaultIsOkay(t *testing.T) {
 	}
 }
 
+func TestTLS13Client(t *testing.T) {
+	c := connectStdlib(t, &stdtls.Config{})
+	st := c.ConnectionState()
+	if st.Version != tls.VersionTLS13 {
+		t.Errorf("Version: want %#04x, got %#04x", tls.VersionTLS13, st.Version)
+	}
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if ci.TLSVersion != "TLS 1.3" {
+		t.Errorf("TLSVersion: want %q, got %q", "TLS 1.3", ci.TLSVersion)
+	}
+
+	if !containsString(ci.KeyShareGroups, "x25519") {
+		t.Errorf("KeyShareGroups: want x25519 included, got %v", ci.KeyShareGroups)
+	}
+	if ci.Negotiated.Curve != "x25519" {
+		t.Errorf("Negotiated.Curve: want %q, got %q", "x25519", ci.Negotiated.Curve)
+	}
+
+	// A client that merely offers TLS 1.3 but can't negotiate it must not be
+	// reported as using it.
+	c = connectStdlib(t, &stdtls.Config{MaxVersion: stdtls.VersionTLS12})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if ci.TLSVersion != "TLS 1.2" {
+		t.Errorf("TLSVersion with MaxVersion TLS 1.2: want %q, got %q", "TLS 1.2", ci.TLSVersion)
+	}
+}
+
+func TestClientHelloExtensions(t *testing.T) {
+	c := connect(t, &tls.Config{})
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if len(ci.ClientHello) == 0 {
+		t.Errorf("ClientHello was empty")
+	}
+	if len(ci.ClientHelloExtensions) == 0 {
+		t.Fatalf("no ClientHello extensions reported")
+	}
+	sawSNI := false
+	for _, ext := range ci.ClientHelloExtensions {
+		if ext.Length != len(ext.Data) {
+			t.Errorf("extension %d: Length %d, but %d bytes of data", ext.Type, ext.Length, len(ext.Data))
+		}
+		if ext.Type == 0 {
+			sawSNI = true
+		}
+	}
+	if !sawSNI {
+		t.Errorf("server_name extension not reported even though connect sets ServerName")
+	}
+}
+
+func TestGroups(t *testing.T) {
+	c := connect(t, &tls.Config{CurvePreferences: []tls.CurveID{tls.CurveP256, tls.X25519}})
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	want := []string{"secp256r1", "x25519"}
+	if !reflect.DeepEqual(ci.OfferedGroups, want) {
+		t.Errorf("OfferedGroups: want %v, got %v", want, ci.OfferedGroups)
+	}
+	// The server's preference wins.
+	if ci.Negotiated.Curve != "x25519" {
+		t.Errorf("Negotiated.Curve: want %q, got %q", "x25519", ci.Negotiated.Curve)
+	}
+	if len(ci.KeyShareGroups) != 0 {
+		t.Errorf("KeyShareGroups: want none from a TLS 1.2 client, got %v", ci.KeyShareGroups)
+	}
+	if ci.PostQuantumReady {
+		t.Errorf("PostQuantumReady was true for a client without post-quantum groups")
+	}
+
+	// tls110's client offers whatever groups it's configured with, even
+	// ones it can't use, which is enough to look post-quantum ready.
+	c = connect(t, &tls.Config{CurvePreferences: []tls.CurveID{0x11ec, tls.X25519}})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	want = []string{"X25519MLKEM768", "x25519"}
+	if !reflect.DeepEqual(ci.OfferedGroups, want) {
+		t.Errorf("OfferedGroups: want %v, got %v", want, ci.OfferedGroups)
+	}
+	if !ci.PostQuantumReady {
+		t.Errorf("PostQuantumReady was false for a client offering X25519MLKEM768")
+	}
+	if ci.LowSecurityGroupSupported {
+		t.Errorf("LowSecurityGroupSupported was true for a client offering only strong groups")
+	}
+	if want := []string{"uncompressed"}; !reflect.DeepEqual(ci.PointFormats, want) {
+		t.Errorf("PointFormats: want %v, got %v", want, ci.PointFormats)
+	}
+
+	c = connect(t, &tls.Config{CurvePreferences: []tls.CurveID{tls.X25519, 16, 26}})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !ci.LowSecurityGroupSupported {
+		t.Errorf("LowSecurityGroupSupported was false for a client offering secp160r1")
+	}
+	if _, ok := ci.WeakGroups["secp160r1"]; !ok {
+		t.Errorf("WeakGroups: want secp160r1 included, got %v", ci.WeakGroups)
+	}
+	if _, ok := ci.WeakGroups["brainpoolP256r1"]; !ok {
+		t.Errorf("WeakGroups: want brainpoolP256r1 included, got %v", ci.WeakGroups)
+	}
+	if ci.Rating != improvable {
+		t.Errorf("rating with secp160r1 offered: want %s, got %s", improvable, ci.Rating)
+	}
+}
+
+func TestRenegotiationAndEMS(t *testing.T) {
+	c := connect(t, &tls.Config{})
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !ci.SecureRenegotiationSupported {
+		t.Errorf("SecureRenegotiationSupported was false but tls110's client sends renegotiation_info")
+	}
+	if ci.EmptyRenegotiationInfoSCSV {
+		t.Errorf("EmptyRenegotiationInfoSCSV was true but the SCSV wasn't sent")
+	}
+	if ci.ExtendedMasterSecretSupported {
+		t.Errorf("ExtendedMasterSecretSupported was true but tls110's client doesn't support it")
+	}
+	if ci.Rating != improvable {
+		t.Errorf("rating without extended master secret: want %s, got %s", improvable, ci.Rating)
+	}
+
+	c = connect(t, &tls.Config{CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, 0x00ff}})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !ci.EmptyRenegotiationInfoSCSV {
+		t.Errorf("EmptyRenegotiationInfoSCSV was false but the SCSV was sent")
+	}
+
+	c = connectStdlib(t, &stdtls.Config{MaxVersion: stdtls.VersionTLS12})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !ci.ExtendedMasterSecretSupported {
+		t.Errorf("ExtendedMasterSecretSupported was false for the stdlib client")
+	}
+	if !ci.SecureRenegotiationSupported {
+		t.Errorf("SecureRenegotiationSupported was false for the stdlib client")
+	}
+}
+
+func TestSignatureAlgorithms(t *testing.T) {
+	c := connect(t, &tls.Config{})
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !containsString(ci.SignatureAlgorithms, "ecdsa_secp256r1_sha256") {
+		t.Errorf("SignatureAlgorithms: want ecdsa_secp256r1_sha256 included, got %v", ci.SignatureAlgorithms)
+	}
+	if len(ci.SignatureAlgorithmsCert) != 0 {
+		t.Errorf("SignatureAlgorithmsCert: want none from tls110's client, got %v", ci.SignatureAlgorithmsCert)
+	}
+	for _, name := range []string{"rsa_pkcs1_sha1", "ecdsa_sha1"} {
+		if !reflect.DeepEqual(ci.WeakSignatureAlgorithms[name], []string{sha1SigReason}) {
+			t.Errorf("WeakSignatureAlgorithms[%s]: want %v, got %v", name, []string{sha1SigReason}, ci.WeakSignatureAlgorithms[name])
+		}
+	}
+	if _, ok := ci.WeakSignatureAlgorithms["rsa_pkcs1_sha256"]; ok {
+		t.Errorf("rsa_pkcs1_sha256 was reported as weak")
+	}
+	if ci.Rating != improvable {
+		t.Errorf("rating with SHA-1 signature algorithms: want %s, got %s", improvable, ci.Rating)
+	}
+
+	c = connectStdlib(t, &stdtls.Config{})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !containsString(ci.SignatureAlgorithmsCert, "ecdsa_secp256r1_sha256") {
+		t.Errorf("SignatureAlgorithmsCert: want ecdsa_secp256r1_sha256 included, got %v", ci.SignatureAlgorithmsCert)
+	}
+}
+
+func TestFallbackSCSVReported(t *testing.T) {
+	c := connect(t, &tls.Config{})
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if ci.FallbackSCSVSent {
+		t.Errorf("FallbackSCSVSent was true for a client that didn't send it")
+	}
+
+	// The server's max version is above TLS 1.1, so without
+	// AllowInappropriateFallback this handshake would be rejected.
+	c = connect(t, &tls.Config{
+		MaxVersion:   tls.VersionTLS11,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_FALLBACK_SCSV},
+	})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if !ci.FallbackSCSVSent {
+		t.Errorf("FallbackSCSVSent was false for a client that sent it")
+	}
+	if ci.FallbackSCSVReason != fallbackSCSVReason {
+		t.Errorf("FallbackSCSVReason: want %q, got %q", fallbackSCSVReason, ci.FallbackSCSVReason)
+	}
+}
+
+func TestDHE(t *testing.T) {
+	dheOnly := []uint16{tls.TLS_DHE_RSA_WITH_AES_128_GCM_SHA256}
+	tests := []struct {
+		name       string
+		conn       func() *conn
+		bits       int
+		logjam     bool
+		weak       bool
+		wantRating rating
+	}{
+		{
+			name: "main server",
+			conn: func() *conn { return connect(t, &tls.Config{CipherSuites: dheOnly}) },
+			bits: 2048,
+		},
+		{
+			name:       "512-bit probe",
+			conn:       func() *conn { return connectDHProbe(t, 512, &tls.Config{CipherSuites: dheOnly}) },
+			bits:       512,
+			logjam:     true,
+			wantRating: bad,
+		},
+		{
+			name:       "1024-bit probe",
+			conn:       func() *conn { return connectDHProbe(t, 1024, &tls.Config{CipherSuites: dheOnly}) },
+			bits:       1024,
+			weak:       true,
+			wantRating: improvable,
+		},
+		{
+			name: "ECDHE client",
+			conn: func() *conn { return connect(t, &tls.Config{}) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ci := pullClientInfo(tt.conn(), defaultRatingPolicy, emptyFingerprintDB)
+			if ci.Negotiated.DHGroupBits != tt.bits {
+				t.Errorf("Negotiated.DHGroupBits: want %d, got %d", tt.bits, ci.Negotiated.DHGroupBits)
+			}
+			if ci.LogjamVuln != tt.logjam {
+				t.Errorf("LogjamVuln: want %v, got %v", tt.logjam, ci.LogjamVuln)
+			}
+			if ci.WeakDHGroupAccepted != tt.weak {
+				t.Errorf("WeakDHGroupAccepted: want %v, got %v", tt.weak, ci.WeakDHGroupAccepted)
+			}
+			if tt.wantRating != "" && ci.Rating != tt.wantRating {
+				t.Errorf("rating: want %s, got %s", tt.wantRating, ci.Rating)
+			}
+		})
+	}
+}
+
+func TestNegotiated(t *testing.T) {
+	c := connectStdlib(t, &stdtls.Config{})
+	ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	want := negotiatedInfo{
+		Version:         "TLS 1.3",
+		CipherSuite:     cipherSuiteName(c.ConnectionState().CipherSuite),
+		Curve:           "x25519",
+		SignatureScheme: "rsa_pss_rsae_sha256",
+	}
+	if ci.Negotiated != want {
+		t.Errorf("TLS 1.3 Negotiated:\nwant %+v\ngot  %+v", want, ci.Negotiated)
+	}
+
+	c = connect(t, &tls.Config{
+		CipherSuites:     []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+		CurvePreferences: []tls.CurveID{tls.CurveP256},
+		NextProtos:       []string{"h2"},
+	})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	want = negotiatedInfo{
+		Version:         "TLS 1.2",
+		CipherSuite:     "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		Curve:           "secp256r1",
+		SignatureScheme: "rsa_pkcs1_sha256",
+		ALPN:            "h2",
+	}
+	if ci.Negotiated != want {
+		t.Errorf("TLS 1.2 Negotiated:\nwant %+v\ngot  %+v", want, ci.Negotiated)
+	}
+
+	c = connect(t, &tls.Config{
+		CipherSuites: []uint16{tls.TLS_RSA_WITH_AES_128_GCM_SHA256},
+		MaxVersion:   tls.VersionTLS12,
+	})
+	ci = pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	want = negotiatedInfo{
+		Version:     "TLS 1.2",
+		CipherSuite: "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	}
+	if ci.Negotiated != want {
+		t.Errorf("RSA key exchange Negotiated:\nwant %+v\ngot  %+v", want, ci.Negotiated)
+	}
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 func TestSweet32(t *testing.T) {
 	type sweetTest struct {
 		rating   rating
@@ -143,17 +434,17 @@ func TestSweet32(t *testing.T) {
 			},
 		},
 		{
-			okay,
+			improvable,
 			[]uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305, tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA, tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA},
 			map[string][]string{},
 		},
 		{
-			okay,
+			improvable,
 			[]uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305, tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA, tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA, greaseCS},
 			map[string][]string{},
 		},
 		{
-			okay,
+			improvable,
 			[]uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305, tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA, tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA, greaseCS, renegCS},
 			map[string][]string{},
 		},
@@ -165,21 +456,21 @@ func TestSweet32(t *testing.T) {
 					CipherSuites: st.suites,
 				}
 				c := connect(t, clientConf)
-				ci := pullClientInfo(c)
+				ci := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
 				t.Logf("#%d, %#v", i, ci)
 
 				if ci.Rating != st.rating {
 					t.Errorf("#%d, Go client rating: want %s, got %s", i, st.rating, ci.Rating)
 				}
-				if len(ci.GivenCipherSuites) != len(st.suites) {
+				if len(ci.SupportedCipherSuites) != len(st.suites) {
 					suites := []string{}
 					for _, cs := range st.suites {
-						suites = append(suites, allCipherSuites[cs])
+						suites = append(suites, cipherSuiteName(cs))
 					}
-					t.Errorf("#%d, num cipher suites given: want %d, got %d (%v, %v)", i, len(st.suites), len(ci.GivenCipherSuites), suites, ci.GivenCipherSuites)
+					t.Errorf("#%d, num cipher suites given: want %d, got %d (%v, %v)", i, len(st.suites), len(ci.SupportedCipherSuites), suites, ci.SupportedCipherSuites)
 				}
-				if !reflect.DeepEqual(st.expected, ci.InsecureCipherSuites) {
-					t.Errorf("#%d, insecure cipher suites found: want %s, got %s", i, st.expected, ci.InsecureCipherSuites)
+				if !reflect.DeepEqual(st.expected, ci.BrokenCipherSuites) {
+					t.Errorf("#%d, insecure cipher suites found: want %s, got %s", i, st.expected, ci.BrokenCipherSuites)
 				}
 			},
 		)
@@ -204,8 +495,13 @@ func init() {
 	rootCA = certs[0]
 }
 
+// devCertTime is a moment inside the validity period of the development
+// certificate in ./config, which has since expired.
+var devCertTime = time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+
 func connect(t *testing.T, clientConf *tls.Config) *conn {
 	clientConf.ServerName = "localhost"
+	clientConf.Time = func() time.Time { return devCertTime }
 
 	// Required to flip on session ticket keys
 	clientConf.ClientSessionCache = tls.NewLRUClientSessionCache(-1)
@@ -215,11 +511,48 @@ func connect(t *testing.T, clientConf *tls.Config) *conn {
 	clientConf.RootCAs = x509.NewCertPool()
 	clientConf.RootCAs.AddCert(rootCA)
 
-	tl, err := tls.Listen("tcp", "localhost:0", serverConf)
+	return connectWith(t, serverConf, func(d *net.Dialer, addr string) (net.Conn, error) {
+		return tls.DialWithDialer(d, "tcp", addr, clientConf)
+	})
+}
+
+// connectDHProbe is like connect but against a DH probe server using a group
+// of the given size.
+func connectDHProbe(t *testing.T, bits int, clientConf *tls.Config) *conn {
+	clientConf.ServerName = "localhost"
+	clientConf.Time = func() time.Time { return devCertTime }
+	clientConf.RootCAs = x509.NewCertPool()
+	clientConf.RootCAs.AddCert(rootCA)
+
+	return connectWith(t, makeDHProbeConfig(serverConf, bits), func(d *net.Dialer, addr string) (net.Conn, error) {
+		return tls.DialWithDialer(d, "tcp", addr, clientConf)
+	})
+}
+
+// connectStdlib is like connect but uses crypto/tls as the client, which,
+// unlike tls110's client, offers TLS 1.3.
+func connectStdlib(t *testing.T, clientConf *stdtls.Config) *conn {
+	clientConf.ServerName = "localhost"
+	clientConf.Time = func() time.Time { return devCertTime }
+	clientConf.RootCAs = x509.NewCertPool()
+	clientConf.RootCAs.AddCert(rootCA)
+
+	return connectWith(t, serverConf, func(d *net.Dialer, addr string) (net.Conn, error) {
+		return stdtls.DialWithDialer(d, "tcp", addr, clientConf)
+	})
+}
+
+func connectWith(t *testing.T, conf *tls.Config, dial func(d *net.Dialer, addr string) (net.Conn, error)) *conn {
+	tl, err := tls.Listen("tcp", "localhost:0", conf)
 	if err != nil {
 		t.Fatalf("NewListener: %s", err)
 	}
-	li := newListener(tl, new(expvar.Map).Init())
+	return connectListener(t, newListener(tl, new(expvar.Map).Init()), dial)
+}
+
+// connectListener dials li, sends a byte and returns the server side of the
+// connection once it's been read.
+func connectListener(t *testing.T, li *listener, dial func(d *net.Dialer, addr string) (net.Conn, error)) *conn {
 	type connRes struct {
 		recv []byte
 		conn *conn
@@ -239,12 +572,13 @@ func connect(t *testing.T, clientConf *tls.Config) *conn {
 		tc := c.(*conn)
 		ch <- connRes{recv: b, conn: tc}
 	}()
-	var c *tls.Conn
+	var c net.Conn
+	var err error
 	for i := 0; i < 10; i++ {
 		d := &net.Dialer{
 			Timeout: 500 * time.Millisecond,
 		}
-		c, err = tls.DialWithDialer(d, "tcp", li.Addr().String(), clientConf)
+		c, err = dial(d, li.Addr().String())
 		if err == nil {
 			break
 		} else {
@@ -0,0 +1,228 @@
+// Package verify checks the signed results of the tls.support API, so that a
+// backend can trust a result a user's browser passed along to it.
+//
+// A result is only fresh for the request that asked for it: pass the nonce
+// (and audience, if any) your backend put in the API request, along with
+// the IP address the user connected to your backend from, in Options.
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Claims bind a result to the request it answered. They're in the "claims"
+// object of the result's JSON.
+type Claims struct {
+	Nonce    string `json:"nonce,omitempty"`
+	Audience string `json:"aud,omitempty"`
+	IssuedAt int64  `json:"iat"` // Unix seconds
+	Expires  int64  `json:"exp"` // Unix seconds
+	ClientIP string `json:"client_ip"`
+}
+
+// Options are what a result's claims must match. Empty fields aren't
+// checked, except for Now, which defaults to the current time.
+type Options struct {
+	Nonce    string
+	Audience string
+	ClientIP string
+	Now      time.Time
+	// Leeway allows for clock skew between us and the API servers.
+	Leeway time.Duration
+}
+
+var (
+	ErrBadSignature = errors.New("verify: bad signature")
+	ErrNoClaims     = errors.New("verify: result has no claims")
+	ErrNotYetValid  = errors.New("verify: result was issued in the future")
+	ErrExpired      = errors.New("verify: result has expired")
+)
+
+// JWS verifies body against the X-Response-JWS header value jws using the
+// JWK Set served at /.well-known/jwks.json, and then checks its claims.
+func JWS(body []byte, jws string, jwks []byte, opts Options) (*Claims, error) {
+	err := DetachedJWS(jwks, jws, body)
+	if err != nil {
+		return nil, err
+	}
+	return checkClaims(body, opts)
+}
+
+// HMAC verifies body against the X-Response-Signature header value sig
+// using the shared secret, and then checks its claims.
+func HMAC(body []byte, sig string, secret []byte, opts Options) (*Claims, error) {
+	got, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return nil, ErrBadSignature
+	}
+	return checkClaims(body, opts)
+}
+
+// HMACWithKID verifies body like HMAC, using the secret in secrets named by
+// kid, the X-Response-Signature-Kid header value. Keep the secrets being
+// rotated out in secrets until the results signed with them have expired.
+func HMACWithKID(body []byte, sig, kid string, secrets map[string][]byte, opts Options) (*Claims, error) {
+	secret, ok := secrets[kid]
+	if !ok {
+		return nil, fmt.Errorf("verify: no secret with kid %#v", kid)
+	}
+	return HMAC(body, sig, secret, opts)
+}
+
+func checkClaims(body []byte, opts Options) (*Claims, error) {
+	var result struct {
+		Claims *Claims `json:"claims"`
+	}
+	err := json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("verify: unable to parse result: %s", err)
+	}
+	c := result.Claims
+	if c == nil {
+		return nil, ErrNoClaims
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if now.Add(opts.Leeway).Before(time.Unix(c.IssuedAt, 0)) {
+		return nil, ErrNotYetValid
+	}
+	if !now.Add(-opts.Leeway).Before(time.Unix(c.Expires, 0)) {
+		return nil, ErrExpired
+	}
+	if opts.Nonce != "" && !hmac.Equal([]byte(c.Nonce), []byte(opts.Nonce)) {
+		return nil, fmt.Errorf("verify: result is for nonce %#v, not %#v", c.Nonce, opts.Nonce)
+	}
+	if opts.Audience != "" && c.Audience != opts.Audience {
+		return nil, fmt.Errorf("verify: result is for audience %#v, not %#v", c.Audience, opts.Audience)
+	}
+	if opts.ClientIP != "" && c.ClientIP != opts.ClientIP {
+		return nil, fmt.Errorf("verify: result is for client IP %s, not %s", c.ClientIP, opts.ClientIP)
+	}
+	return c, nil
+}
+
+type jwk struct {
+	KTY string `json:"kty"`
+	CRV string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	KID string `json:"kid"`
+	Alg string `json:"alg"`
+}
+
+type jwsHeader struct {
+	Alg string `json:"alg"`
+	KID string `json:"kid"`
+}
+
+// DetachedJWS checks that jws is a compact JWS of payload, with the payload
+// left out, signed by the key in the JWK Set jwks its header names. It only
+// checks the signature, not any claims.
+func DetachedJWS(jwks []byte, jws string, payload []byte) error {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return errors.New("verify: not a detached compact JWS")
+	}
+	hb, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("verify: unable to decode JWS header: %s", err)
+	}
+	var h jwsHeader
+	err = json.Unmarshal(hb, &h)
+	if err != nil {
+		return fmt.Errorf("verify: unable to parse JWS header: %s", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrBadSignature
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err = json.Unmarshal(jwks, &set)
+	if err != nil {
+		return fmt.Errorf("verify: unable to parse JWK Set: %s", err)
+	}
+	var key *jwk
+	for i := range set.Keys {
+		if set.Keys[i].KID == h.KID {
+			key = &set.Keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("verify: no key with kid %#v; fetch the JWK Set again if it was rotated", h.KID)
+	}
+	// Only trust the algorithm the key is for, not the one the JWS claims.
+	if key.Alg != h.Alg {
+		return fmt.Errorf("verify: JWS alg %#v doesn't match key %#v's %#v", h.Alg, key.KID, key.Alg)
+	}
+	input := []byte(parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload))
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return fmt.Errorf("verify: key %#v is malformed: %s", key.KID, err)
+	}
+	switch key.Alg {
+	case "EdDSA":
+		if key.CRV != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return fmt.Errorf("verify: key %#v is not an Ed25519 key", key.KID)
+		}
+		if !ed25519.Verify(ed25519.PublicKey(x), input, sig) {
+			return ErrBadSignature
+		}
+		return nil
+	case "ES256", "ES384":
+		y, err := base64.RawURLEncoding.DecodeString(key.Y)
+		if err != nil {
+			return fmt.Errorf("verify: key %#v is malformed: %s", key.KID, err)
+		}
+		var curve elliptic.Curve
+		var digest []byte
+		if key.Alg == "ES256" {
+			curve = elliptic.P256()
+			d := sha256.Sum256(input)
+			digest = d[:]
+		} else {
+			curve = elliptic.P384()
+			d := sha512.Sum384(input)
+			digest = d[:]
+		}
+		if key.CRV != curve.Params().Name {
+			return fmt.Errorf("verify: key %#v is on %s, not %s", key.KID, key.CRV, curve.Params().Name)
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return fmt.Errorf("verify: key %#v is not on its curve", key.KID)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return ErrBadSignature
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return ErrBadSignature
+		}
+		return nil
+	}
+	return fmt.Errorf("verify: unsupported JWS alg %#v", key.Alg)
+}
@@ -0,0 +1,163 @@
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	issued = time.Unix(1700000000, 0)
+	body   = []byte(`{"tls_version": "TLS 1.3", "claims": {"nonce": "n0nce", "aud": "example.com", "iat": 1700000000, "exp": 1700000300, "client_ip": "192.0.2.1"}}`)
+	secret = []byte("shh")
+)
+
+func hmacSig(b []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(b)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestHMACClaims(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{"all match", Options{Nonce: "n0nce", Audience: "example.com", ClientIP: "192.0.2.1", Now: issued.Add(time.Minute)}, ""},
+		{"unchecked", Options{Now: issued}, ""},
+		{"expired", Options{Now: issued.Add(5 * time.Minute)}, ErrExpired.Error()},
+		{"expired within leeway", Options{Now: issued.Add(5 * time.Minute), Leeway: time.Second}, ""},
+		{"issued in the future", Options{Now: issued.Add(-time.Minute)}, ErrNotYetValid.Error()},
+		{"other nonce", Options{Nonce: "other", Now: issued}, "nonce"},
+		{"other audience", Options{Audience: "example.org", Now: issued}, "audience"},
+		{"other client IP", Options{ClientIP: "192.0.2.2", Now: issued}, "client IP"},
+	}
+	for _, tt := range tests {
+		c, err := HMAC(body, hmacSig(body), secret, tt.opts)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: %s", tt.name, err)
+			} else if c.Nonce != "n0nce" || c.Expires != 1700000300 {
+				t.Errorf("%s: wrong claims %+v", tt.name, c)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: want error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	_, err := HMAC(body, hmacSig(body), []byte("wrong"), Options{Now: issued})
+	if err != ErrBadSignature {
+		t.Errorf("wrong secret: want ErrBadSignature, got %v", err)
+	}
+	noClaims := []byte(`{"tls_version": "TLS 1.3"}`)
+	_, err = HMAC(noClaims, hmacSig(noClaims), secret, Options{Now: issued})
+	if err != ErrNoClaims {
+		t.Errorf("no claims: want ErrNoClaims, got %v", err)
+	}
+}
+
+func TestHMACWithKID(t *testing.T) {
+	// Mid-rotation, results signed with either secret are accepted.
+	secrets := map[string][]byte{"old": secret, "new": []byte("shh2")}
+	sign := func(secret []byte) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	opts := Options{Now: issued}
+	for kid, s := range secrets {
+		if _, err := HMACWithKID(body, sign(s), kid, secrets, opts); err != nil {
+			t.Errorf("%s: %s", kid, err)
+		}
+	}
+
+	_, err := HMACWithKID(body, sign(secrets["old"]), "new", secrets, opts)
+	if err != ErrBadSignature {
+		t.Errorf("other kid's secret: want ErrBadSignature, got %v", err)
+	}
+	// Once the old secret is dropped, its results no longer verify.
+	delete(secrets, "old")
+	_, err = HMACWithKID(body, sign(secret), "old", secrets, opts)
+	if err == nil || !strings.Contains(err.Error(), "no secret with kid") {
+		t.Errorf("retired kid: want a no secret error, got %v", err)
+	}
+}
+
+func TestJWS(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, err := json.Marshal(map[string][]jwk{"keys": {
+		{KTY: "OKP", CRV: "Ed25519", X: b64(edPub), KID: "ed", Alg: "EdDSA"},
+		{KTY: "EC", CRV: "P-256", X: b64(ecPriv.X.FillBytes(make([]byte, 32))), Y: b64(ecPriv.Y.FillBytes(make([]byte, 32))), KID: "ec", Alg: "ES256"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	detached := func(alg, kid string, sign func(input []byte) []byte) string {
+		header := b64([]byte(fmt.Sprintf(`{"alg":%q,"kid":%q}`, alg, kid)))
+		return header + ".." + b64(sign([]byte(header+"."+b64(body))))
+	}
+	signEd := func(input []byte) []byte { return ed25519.Sign(edPriv, input) }
+	signEC := func(input []byte) []byte {
+		d := sha256.Sum256(input)
+		r, s, err := ecdsa.Sign(rand.Reader, ecPriv, d[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	opts := Options{Nonce: "n0nce", Now: issued}
+	for _, jws := range []string{detached("EdDSA", "ed", signEd), detached("ES256", "ec", signEC)} {
+		if _, err := JWS(body, jws, jwks, opts); err != nil {
+			t.Errorf("%s: %s", jws, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		jws     string
+		wantErr string
+	}{
+		{"unknown kid", detached("EdDSA", "other", signEd), "no key with kid"},
+		{"alg mismatch", detached("ES256", "ed", signEd), "doesn't match"},
+		{"wrong key", detached("EdDSA", "ed", func(input []byte) []byte {
+			_, other, _ := ed25519.GenerateKey(rand.Reader)
+			return ed25519.Sign(other, input)
+		}), ErrBadSignature.Error()},
+		{"attached payload", strings.Replace(detached("EdDSA", "ed", signEd), "..", "."+b64(body)+".", 1), "not a detached"},
+	}
+	for _, tt := range tests {
+		_, err := JWS(body, tt.jws, jwks, opts)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: want error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	_, err = JWS(body, detached("EdDSA", "ed", signEd), jwks, Options{Now: issued.Add(time.Hour)})
+	if err != ErrExpired {
+		t.Errorf("expired: want ErrExpired, got %v", err)
+	}
+}
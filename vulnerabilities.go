@@ -0,0 +1,197 @@
+package main
+
+import (
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+// vulnerability is an entry in the catalog of named TLS attacks. Its ID is
+// stable, so API users can key on it rather than on the English reasons.
+type vulnerability struct {
+	ID        string
+	Title     string
+	CVE       string
+	Severity  severity
+	Reference string
+
+	// affects reports whether the client is vulnerable, and which of the
+	// cipher suites it offered expose it.
+	affects func(d *clientInfo, st *tls.ConnectionState) (bool, []string)
+}
+
+// vulnerabilityFinding is a vulnerability the client has, as reported to the
+// client.
+type vulnerabilityFinding struct {
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	CVE            string   `json:"cve"`
+	Severity       severity `json:"severity"`
+	AffectedSuites []string `json:"affected_suites"`
+	Reference      string   `json:"reference"`
+}
+
+var vulnerabilities = []vulnerability{
+	{
+		ID:        "beast",
+		Title:     "BEAST",
+		CVE:       "CVE-2011-3389",
+		Severity:  severityHigh,
+		Reference: "https://en.wikipedia.org/wiki/Transport_Layer_Security#BEAST_attack",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			return d.BEASTVuln, offeredSuites(st, isCBC)
+		},
+	},
+	{
+		ID:        "poodle",
+		Title:     "POODLE",
+		CVE:       "CVE-2014-3566",
+		Severity:  severityHigh,
+		Reference: "https://www.openssl.org/~bodo/ssl-poodle.pdf",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			suites := offeredSuites(st, isCBC)
+			return d.version == tls.VersionSSL30 && len(suites) != 0, suites
+		},
+	},
+	{
+		ID:        "freak",
+		Title:     "FREAK",
+		CVE:       "CVE-2015-0204",
+		Severity:  severityCritical,
+		Reference: "https://freakattack.com/",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			suites := offeredSuites(st, func(cs cipherSuite) bool { return cs.Export && cs.KeyExchange == "RSA" })
+			return len(suites) != 0, suites
+		},
+	},
+	{
+		ID:        "logjam",
+		Title:     "Logjam",
+		CVE:       "CVE-2015-4000",
+		Severity:  severityCritical,
+		Reference: "https://weakdh.org/",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			suites := offeredSuites(st, func(cs cipherSuite) bool {
+				return cs.Export && (cs.KeyExchange == "DHE" || cs.KeyExchange == "DH")
+			})
+			return d.LogjamVuln || len(suites) != 0, suites
+		},
+	},
+	{
+		ID:        "sweet32",
+		Title:     "Sweet32",
+		CVE:       "CVE-2016-2183",
+		Severity:  severityHigh,
+		Reference: "https://sweet32.info/",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			suites := []string{}
+			for _, s := range d.SupportedCipherSuites {
+				for _, r := range d.BrokenCipherSuites[s] {
+					if r == sweet32Reason {
+						suites = append(suites, s)
+						break
+					}
+				}
+			}
+			return len(suites) != 0, suites
+		},
+	},
+	{
+		ID:        "crime",
+		Title:     "CRIME",
+		CVE:       "CVE-2012-4929",
+		Severity:  severityHigh,
+		Reference: "https://en.wikipedia.org/wiki/CRIME",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			return d.TLSCompressionSupported, []string{}
+		},
+	},
+	{
+		// Encrypt-then-MAC moves the MAC check ahead of the padding
+		// check, closing the timing side channel.
+		ID:        "lucky13",
+		Title:     "Lucky Thirteen",
+		CVE:       "CVE-2013-0169",
+		Severity:  severityLow,
+		Reference: "https://www.isg.rhul.ac.uk/tls/Lucky13.html",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			suites := offeredSuites(st, isCBC)
+			return len(suites) != 0 && !d.EncryptThenMACSupported, suites
+		},
+	},
+	{
+		ID:        "rc4_biases",
+		Title:     "RC4 keystream biases",
+		CVE:       "CVE-2013-2566",
+		Severity:  severityHigh,
+		Reference: "https://www.rc4nomore.com/",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			suites := offeredSuites(st, func(cs cipherSuite) bool { return cs.Cipher == "RC4" })
+			return len(suites) != 0, suites
+		},
+	},
+	{
+		ID:        "insecure_renegotiation",
+		Title:     "Insecure renegotiation",
+		CVE:       "CVE-2009-3555",
+		Severity:  severityMedium,
+		Reference: "https://www.rfc-editor.org/rfc/rfc5746",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			return d.version != tls.VersionTLS13 && !d.SecureRenegotiationSupported, []string{}
+		},
+	},
+	{
+		ID:        "triple_handshake",
+		Title:     "Triple handshake",
+		CVE:       "CVE-2014-1295",
+		Severity:  severityMedium,
+		Reference: "https://mitls.org/pages/attacks/3SHAKE",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			return d.version != tls.VersionTLS13 && !d.ExtendedMasterSecretSupported, []string{}
+		},
+	},
+	{
+		ID:        "sloth",
+		Title:     "SLOTH",
+		CVE:       "CVE-2015-7575",
+		Severity:  severityHigh,
+		Reference: "https://mitls.org/pages/attacks/SLOTH",
+		affects: func(d *clientInfo, st *tls.ConnectionState) (bool, []string) {
+			return d.md5SigSupported, []string{}
+		},
+	},
+}
+
+// findVulnerabilities returns the catalog entries the client is vulnerable
+// to, in catalog order.
+func findVulnerabilities(d *clientInfo, st *tls.ConnectionState) []vulnerabilityFinding {
+	found := []vulnerabilityFinding{}
+	for _, v := range vulnerabilities {
+		ok, suites := v.affects(d, st)
+		if !ok {
+			continue
+		}
+		found = append(found, vulnerabilityFinding{
+			ID:             v.ID,
+			Title:          v.Title,
+			CVE:            v.CVE,
+			Severity:       v.Severity,
+			AffectedSuites: suites,
+			Reference:      v.Reference,
+		})
+	}
+	return found
+}
+
+func isCBC(cs cipherSuite) bool { return cs.Mode == "CBC" }
+
+// offeredSuites names the registry cipher suites the client offered that
+// match, in the order offered.
+func offeredSuites(st *tls.ConnectionState, match func(cs cipherSuite) bool) []string {
+	names := []string{}
+	for _, id := range st.ClientCipherSuites {
+		cs, ok := cipherSuites[id]
+		if ok && !cs.Signaling && match(cs) {
+			names = append(names, cs.Name)
+		}
+	}
+	return names
+}
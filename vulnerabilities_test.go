@@ -0,0 +1,65 @@
+package main
+
+import (
+	stdtls "crypto/tls"
+	"reflect"
+	"testing"
+
+	tls "github.com/sullivanmatt/check.tls.support/tls110"
+)
+
+func vulnerabilityIDs(found []vulnerabilityFinding) []string {
+	ids := []string{}
+	for _, v := range found {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func TestVulnerabilitiesModernClient(t *testing.T) {
+	c := connectStdlib(t, &stdtls.Config{CipherSuites: []uint16{stdtls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	if len(d.Vulnerabilities) != 0 {
+		t.Errorf("want no vulnerabilities, got %v", vulnerabilityIDs(d.Vulnerabilities))
+	}
+}
+
+func TestVulnerabilitiesOldClient(t *testing.T) {
+	c := connect(t, &tls.Config{
+		MaxVersion: tls.VersionTLS10,
+		CipherSuites: []uint16{
+			tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
+			tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_RSA_WITH_RC4_128_SHA,
+		},
+	})
+	d := pullClientInfo(c, defaultRatingPolicy, emptyFingerprintDB)
+	want := []string{"sweet32", "lucky13", "rc4_biases", "triple_handshake"}
+	if got := vulnerabilityIDs(d.Vulnerabilities); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	wantSuites := map[string][]string{
+		"sweet32":          {"TLS_RSA_WITH_3DES_EDE_CBC_SHA"},
+		"lucky13":          {"TLS_RSA_WITH_3DES_EDE_CBC_SHA", "TLS_RSA_WITH_AES_128_CBC_SHA"},
+		"rc4_biases":       {"TLS_RSA_WITH_RC4_128_SHA"},
+		"triple_handshake": {},
+	}
+	for _, v := range d.Vulnerabilities {
+		if !reflect.DeepEqual(v.AffectedSuites, wantSuites[v.ID]) {
+			t.Errorf("%s: affected suites: want %v, got %v", v.ID, wantSuites[v.ID], v.AffectedSuites)
+		}
+		if v.CVE == "" || v.Reference == "" || !severities[v.Severity] {
+			t.Errorf("%s: incomplete catalog entry %#v", v.ID, v)
+		}
+	}
+}
+
+func TestVulnerabilityCatalogIDsUnique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, v := range vulnerabilities {
+		if seen[v.ID] {
+			t.Errorf("duplicate vulnerability ID %#v", v.ID)
+		}
+		seen[v.ID] = true
+	}
+}